@@ -0,0 +1,103 @@
+// Package export implements the "export" subcommand.
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+
+	_export "github.com/slewiskelly/ock/internal/pkg/export"
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+)
+
+// Export implements the "export" subcommand.
+type Export struct {
+	expr   string
+	glob   string
+	schema string
+	sqlite string
+}
+
+// Name returns the name of the subcommand.
+func (*Export) Name() string {
+	return "export"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Export) Synopsis() string {
+	return "exports the metadata of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Export) Usage() string {
+	return `ock export -sqlite <database> [flags] <path>
+
+Metadata is exported to a "documents" table, containing the name, start and end
+of each file, along with a "meta_<field>" column for each (non-list) field, with
+nested fields being flattened. List fields are normalized into a
+"documents_<field>" table, containing the "document_id", "position" and "value"
+of each element.
+
+Columns are inferred from the #Metadata definition of the schema, if any, and
+from the metadata of files. Existing tables are replaced.
+
+Example:
+  ock export -sqlite docs.db .
+  sqlite3 docs.db 'SELECT value, COUNT(*) FROM documents_tags GROUP BY value'
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (e *Export) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&e.expr, "e", "", "expression to filter files")
+	f.StringVar(&e.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&e.schema, "schema", ".schema.cue", "location of the schema file to infer columns from (empty to infer from metadata only)")
+	f.StringVar(&e.sqlite, "sqlite", "", "location of the SQLite database to export to")
+}
+
+// Execute executes the subcommand.
+func (e *Export) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := e.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (e *Export) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	if e.sqlite == "" {
+		return errors.New("no database provided, specify -sqlite")
+	}
+
+	opts := []_export.Option{_export.Expr(e.expr), _export.Glob(e.glob)}
+
+	// The default schema is optional, columns otherwise being inferred from
+	// metadata only.
+	if _, err := os.Stat(e.schema); e.schema == ".schema.cue" && errors.Is(err, os.ErrNotExist) {
+		e.schema = ""
+	}
+
+	if e.schema != "" {
+		v, err := _schema.Load(e.schema)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, _export.Schema(v))
+	}
+
+	return _export.SQLite(e.sqlite, fs.Arg(0), opts...)
+}
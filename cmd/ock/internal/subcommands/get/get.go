@@ -3,15 +3,23 @@ package get
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
 
+	"cuelang.org/go/cue"
 	"github.com/google/subcommands"
 	"sigs.k8s.io/yaml"
 
+	"github.com/slewiskelly/ock/internal/pkg/fields"
 	_get "github.com/slewiskelly/ock/internal/pkg/get"
 	"github.com/slewiskelly/ock/internal/pkg/report"
 )
@@ -19,8 +27,13 @@ import (
 // Get implements the "get" subcommand.
 type Get struct {
 	def      string
+	desc     bool
 	expr     string
+	fields   string
 	format   string
+	limit    int
+	offset   int
+	sort     string
 	validate bool
 	schema   string
 }
@@ -44,8 +57,13 @@ func (*Get) Usage() string {
 // SetFlags sets the flags specific to the subcommand.
 func (g *Get) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&g.expr, "e", "", "expression to filter files")
-	f.StringVar(&g.format, "f", "yaml", "display format (json | yaml)")
+	f.StringVar(&g.fields, "fields", "", "comma-separated fields to display as columns (csv | table | tsv), default all")
+	f.StringVar(&g.format, "f", "yaml", "display format (csv | json | jsonl | table | tsv | yaml)")
 	f.StringVar(&g.schema, "s", ".schemacue", "location of the schema file to validate against")
+	f.BoolVar(&g.desc, "desc", false, "sort in descending order")
+	f.IntVar(&g.limit, "limit", 0, "maximum number of files to display (0 for no limit)")
+	f.IntVar(&g.offset, "offset", 0, "number of files to skip")
+	f.StringVar(&g.sort, "sort", "", "path of the field to sort files by")
 }
 
 // Execute executes the subcommand.
@@ -73,17 +91,46 @@ func (g *Get) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}
 		opts = append(opts, _get.Expr(g.expr))
 	}
 
+	if g.sort != "" {
+		opts = append(opts, _get.Sort(g.sort, g.desc))
+	}
+
+	opts = append(opts, _get.Offset(g.offset), _get.Limit(g.limit))
+
+	if g.format == "jsonl" {
+		return displayJSONL(fs.Arg(0), opts...)
+	}
+
 	r, err := _get.Get(fs.Arg(0), opts...)
 	if err != nil {
 		return err
 	}
 
-	return display(r, g.format)
+	return display(r, g.format, columns(g.fields))
+}
+
+// columns returns the field names of a comma-separated list, ignoring any
+// surrounding whitespace.
+func columns(s string) []string {
+	var cols []string
 
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
 }
 
-func display(r report.Report, f string) error {
+func display(r report.Report, f string, cols []string) error {
 	switch f {
+	case "csv":
+		return displayCSV(r, cols, ',')
+	case "table":
+		return displayTable(r, cols)
+	case "tsv":
+		return displayCSV(r, cols, '\t')
 	case "json":
 		return displayJSON(r)
 	case "yaml":
@@ -111,6 +158,17 @@ func displayJSON(r report.Report) error {
 	return nil
 }
 
+// displayJSONL displays the metadata of each file, as a single line of JSON, as
+// soon as it is retrieved.
+func displayJSONL(path string, opts ..._get.Option) error {
+	e := json.NewEncoder(os.Stdout)
+	e.SetEscapeHTML(false)
+
+	return _get.Walk(path, func(f *report.File) error {
+		return e.Encode(f)
+	}, opts...)
+}
+
 func displayYAML(r report.Report) error {
 	var b []byte
 	var err error
@@ -128,3 +186,117 @@ func displayYAML(r report.Report) error {
 
 	return nil
 }
+
+func displayCSV(r report.Report, names []string, sep rune) error {
+	cols, rows := flatten(r, names)
+
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = sep
+
+	w.Write(append([]string{"file"}, cols...))
+	w.WriteAll(rows)
+
+	return w.Error()
+}
+
+func displayTable(r report.Report, names []string) error {
+	cols, rows := flatten(r, names)
+
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	header := append([]string{"File"}, cols...)
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for i, h := range header {
+		header[i] = strings.Repeat("-", len(h))
+	}
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		for i, c := range row {
+			row[i] = escape(c)
+		}
+
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	tw.Flush()
+	fmt.Println(w.String())
+
+	return nil
+}
+
+// escape escapes control characters, such as tabs and newlines, which would
+// otherwise break the layout of a table.
+func escape(s string) string {
+	if !strings.ContainsFunc(s, unicode.IsControl) {
+		return s
+	}
+
+	q := strconv.Quote(s)
+
+	return q[1 : len(q)-1]
+}
+
+// flatten flattens the metadata of each file into a row, with a column for each
+// of the given field names or, if none are given, the union of all fields.
+//
+// Nested fields are separated by ".", and list elements by ", ".
+func flatten(r report.Report, names []string) (cols []string, rows [][]string) {
+	values := make([]map[string]string, len(r))
+
+	for i, f := range r {
+		values[i] = make(map[string]string)
+		flattenValue(f.Metadata, values[i])
+	}
+
+	cols = names
+
+	if len(cols) == 0 {
+		seen := make(map[string]bool)
+
+		for _, v := range values {
+			for k := range v {
+				if !seen[k] {
+					seen[k] = true
+					cols = append(cols, k)
+				}
+			}
+		}
+
+		slices.Sort(cols)
+	}
+
+	for i, f := range r {
+		row := []string{f.Name}
+
+		for _, c := range cols {
+			row = append(row, values[i][c])
+		}
+
+		rows = append(rows, row)
+	}
+
+	return cols, rows
+}
+
+// flattenValue records each (non-struct) field of v, with list elements
+// separated by ", ".
+func flattenValue(v cue.Value, m map[string]string) {
+	fields.Walk(v, func(f fields.Field) {
+		if f.Struct() {
+			return
+		}
+
+		var elems []string
+
+		for _, e := range fields.Elems(f.Value) {
+			elems = append(elems, fields.Format(e))
+		}
+
+		m[f.String()] = strings.Join(elems, ", ")
+	})
+}
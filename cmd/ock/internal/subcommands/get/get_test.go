@@ -0,0 +1,40 @@
+package get
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"title", []string{"title"}},
+		{"title,owner", []string{"title", "owner"}},
+		{"title, owner", []string{"title", "owner"}},
+		{" title , meta.owner ,", []string{"title", "meta.owner"}},
+	} {
+		if got := columns(tc.in); !slices.Equal(got, tc.want) {
+			t.Errorf("columns(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"a\tb", `a\tb`},
+		{"a\nb", `a\nb`},
+		{`"quoted"`, `"quoted"`},
+		{"ünïcode", "ünïcode"},
+	} {
+		if got := escape(tc.in); got != tc.want {
+			t.Errorf("escape(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
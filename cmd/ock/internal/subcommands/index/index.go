@@ -0,0 +1,148 @@
+// Package index implements the "index" subcommand.
+package index
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	_index "github.com/slewiskelly/ock/internal/pkg/index"
+)
+
+// Index implements the "index" subcommand.
+type Index struct {
+	desc   bool
+	expr   string
+	format string
+	glob   string
+	output string
+	sort   string
+	tmpl   string
+}
+
+// Name returns the name of the subcommand.
+func (*Index) Name() string {
+	return "index"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Index) Synopsis() string {
+	return "renders an index of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Index) Usage() string {
+	return `ock index [flags] <path>
+
+The template is either the name of a built-in template (` + strings.Join(_index.Templates(), " | ") + `), or
+the location of a Go template file. Template files with an ".html" extension,
+optionally followed by ".tmpl", are rendered as HTML.
+
+Templates are executed with the following data:
+
+  .Documents          documents to be indexed, each having:
+    .Name             name of the file
+    .Link             location of the file, relative to the index
+    .Metadata         file's metadata
+    .Field <path>     value of a (nested) field
+    .Title            title of the document, falling back to its name
+  .Fields             union of the (top-level) fields of all documents
+
+Along with the following functions:
+
+  format <value>      formats a value, joining list elements with ", "
+  groupBy <path> <documents>
+                      groups documents (.Key, .Documents) by each distinct value
+                      of a field
+  inline <value>      formats a value on a single line, for use within a table
+                      cell or heading, escaping "|" in markdown
+  repeat <s> <n>      repeats a string a number of times
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (i *Index) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&i.desc, "desc", false, "sort in descending order")
+	f.StringVar(&i.expr, "e", "", "expression to filter files")
+	f.StringVar(&i.format, "f", "md", "format of built-in templates (html | md)")
+	f.StringVar(&i.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&i.output, "o", "", "location to write the index to (default stdout)")
+	f.StringVar(&i.sort, "sort", "", "path of the field to sort files by")
+	f.StringVar(&i.tmpl, "t", "table", "built-in template name, or location of a template file")
+}
+
+// Execute executes the subcommand.
+func (i *Index) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := i.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (i *Index) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	if i.format != "html" && i.format != "md" {
+		return errors.New("unknown output format")
+	}
+
+	opts := []_index.Option{_index.Expr(i.expr), _index.Glob(i.glob), _index.HTML(i.format == "html")}
+
+	if i.sort != "" {
+		opts = append(opts, _index.Sort(i.sort, i.desc))
+	}
+
+	if i.output == "" {
+		return write(os.Stdout, func(w io.Writer) error {
+			return _index.Index(w, fs.Arg(0), i.tmpl, opts...)
+		})
+	}
+
+	opts = append(opts, _index.Base(filepath.Dir(i.output)))
+
+	return writeFile(i.output, func(w io.Writer) error {
+		return _index.Index(w, fs.Arg(0), i.tmpl, opts...)
+	})
+}
+
+// write renders to w, only once rendering succeeds, such that nothing is
+// written if it fails.
+func write(w io.Writer, render func(io.Writer) error) error {
+	b := new(bytes.Buffer)
+
+	if err := render(b); err != nil {
+		return err
+	}
+
+	_, err := b.WriteTo(w)
+
+	return err
+}
+
+// writeFile renders to the named file, only once rendering succeeds, such that
+// an existing file is left intact if it fails.
+func writeFile(name string, render func(io.Writer) error) error {
+	b := new(bytes.Buffer)
+
+	if err := render(b); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, b.Bytes(), 0o644)
+}
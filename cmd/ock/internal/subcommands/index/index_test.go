@@ -0,0 +1,57 @@
+package index
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		render func(io.Writer) error
+		want   string
+		err    bool
+	}{
+		{
+			name: "success",
+			render: func(w io.Writer) error {
+				_, err := io.WriteString(w, "rendered")
+				return err
+			},
+			want: "rendered",
+		},
+		{
+			name: "failure",
+			render: func(w io.Writer) error {
+				io.WriteString(w, "partial")
+				return errors.New("bad template")
+			},
+			want: "existing",
+			err:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), "README.md")
+
+			if err := os.WriteFile(p, []byte("existing"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := writeFile(p, tc.render); (err != nil) != tc.err {
+				t.Errorf("writeFile() = %v, want error: %v", err, tc.err)
+			}
+
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(b); got != tc.want {
+				t.Errorf("writeFile() wrote %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/google/subcommands"
 
@@ -14,9 +17,13 @@ import (
 
 // Init implements the "init" subcommand.
 type Init struct {
-	def    string
-	force  bool
-	schema string
+	def      string
+	force    bool
+	from     string
+	infer    string
+	list     bool
+	schema   string
+	template string
 }
 
 // Name returns the name of the subcommand.
@@ -26,19 +33,48 @@ func (*Init) Name() string {
 
 // Synopsis returns a one-line summary of the subcommand.
 func (*Init) Synopsis() string {
-	return "initializes a default schema, in the current working directory"
+	return "initializes a schema, in the current working directory"
 }
 
 // Usage returns a longer explanation and/or usage example(s) of the subcommand.
 func (*Init) Usage() string {
-	return `ock init [flags]
+	return `ock init [flags] [template]
+
+The schema is initialized from a template, either built-in (see
+-list-templates), or a local file, given by -template or as an argument:
+
+  ock init runbook
+  ock init -template ./schemas/metadata.cue
+
+-template may also specify a directory of templates (<name>.cue), with the name
+of the template given as an argument:
+
+  ock init -template ./schemas runbook
+
+An existing JSON Schema, or OpenAPI, definition may be imported with -from. The
+root of a JSON Schema, or an OpenAPI component named "Metadata" (or the only
+component), becomes the #Metadata definition. Another component can be
+selected by appending its name to the filename, for example:
+
+  ock init -from openapi.yaml#Frontmatter
+
+Alternatively, the schema may be inferred from the metadata of existing files
+with -infer, which cannot be combined with -from. Fields present in every file
+are required, string fields with few recurring values become enumerations, and
+dates are constrained as such.
+
+  ock init -infer docs
 `
 }
 
 // SetFlags sets the flags specific to the subcommand.
 func (i *Init) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&i.force, "force", false, "force initialization if a schema file already exists")
+	f.StringVar(&i.from, "from", "", "location of a JSON Schema, or OpenAPI, file to import the schema from")
+	f.StringVar(&i.infer, "infer", "", "path under which to infer the schema from the metadata of existing files")
+	f.BoolVar(&i.list, "list-templates", false, "list available templates")
 	f.StringVar(&i.schema, "schema", ".schema.cue", "location of the schema file to validate against")
+	f.StringVar(&i.template, "template", "default", "name, or location, of the template (or directory of templates) to initialize from")
 }
 
 // Execute executes the subcommand.
@@ -54,5 +90,66 @@ func (i *Init) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{
 }
 
 func (i *Init) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
-	return _init.Init(i.schema, i.def)
+	var dir string
+
+	if fs.NArg() > 1 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args()[1:], " "))
+	}
+
+	i.def = i.template
+
+	switch fi, err := os.Stat(i.template); {
+	case err == nil && fi.IsDir() && !builtin(i.template):
+		dir, i.def = i.template, fs.Arg(0)
+	case fs.NArg() > 0 && set(fs, "template"):
+		return fmt.Errorf("unexpected argument %q, the template is already given by -template", fs.Arg(0))
+	case fs.NArg() > 0:
+		i.def = fs.Arg(0)
+	}
+
+	if i.list {
+		t, err := _init.Templates(dir)
+		if err != nil {
+			return err
+		}
+
+		return display(t)
+	}
+
+	return _init.Init(i.schema, i.def, _init.Dir(dir), _init.Force(i.force), _init.From(i.from), _init.Infer(i.infer))
+}
+
+// set reports whether the flag of the given name was set.
+func set(fs *flag.FlagSet, name string) bool {
+	var ok bool
+
+	fs.Visit(func(f *flag.Flag) {
+		ok = ok || f.Name == name
+	})
+
+	return ok
+}
+
+// builtin reports whether name is that of a built-in template, rather than the
+// location of a directory of templates.
+func builtin(name string) bool {
+	t, _ := _init.Templates("")
+
+	return slices.ContainsFunc(t, func(x _init.Template) bool {
+		return x.Name == name
+	})
+}
+
+func display(t []_init.Template) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	for _, x := range t {
+		fmt.Fprintf(tw, "%s\t%s\n", x.Name, x.Description)
+	}
+
+	tw.Flush()
+	fmt.Print(w.String())
+
+	return nil
 }
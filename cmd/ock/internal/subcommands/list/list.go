@@ -18,8 +18,13 @@ import (
 
 // List implements the "list" subcommand.
 type List struct {
-	expr   string
-	format string
+	desc    bool
+	expr    string
+	format  string
+	groupBy string
+	limit   int
+	offset  int
+	sort    string
 }
 
 // Name returns the name of the subcommand.
@@ -42,6 +47,11 @@ func (*List) Usage() string {
 func (l *List) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&l.expr, "e", "", "expression to filter files")
 	f.StringVar(&l.format, "f", "summary", "display format (json | summary)")
+	f.BoolVar(&l.desc, "desc", false, "sort in descending order")
+	f.StringVar(&l.groupBy, "group-by", "", "path of the field to group files by")
+	f.IntVar(&l.limit, "limit", 0, "maximum number of files to list (0 for no limit)")
+	f.IntVar(&l.offset, "offset", 0, "number of files to skip")
+	f.StringVar(&l.sort, "sort", "", "path of the field to sort files by")
 }
 
 // Execute executes the subcommand.
@@ -69,6 +79,21 @@ func (l *List) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{
 		opts = append(opts, _list.Expr(l.expr))
 	}
 
+	if l.sort != "" {
+		opts = append(opts, _list.Sort(l.sort, l.desc))
+	}
+
+	opts = append(opts, _list.Offset(l.offset), _list.Limit(l.limit))
+
+	if l.groupBy != "" {
+		g, err := _list.GroupBy(fs.Arg(0), l.groupBy, opts...)
+		if err != nil {
+			return err
+		}
+
+		return displayGroups(g, l.groupBy, l.format)
+	}
+
 	f, err := _list.List(fs.Arg(0), opts...)
 	if err != nil {
 		return err
@@ -88,7 +113,18 @@ func display(s []string, f string) error {
 	}
 }
 
-func displayJSON(s []string) error {
+func displayGroups(g []_list.Group, by, f string) error {
+	switch f {
+	case "json":
+		return displayJSON(g)
+	case "summary":
+		return displayGroupsSummary(g, by)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayJSON(s any) error {
 	b, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
 		return err
@@ -115,3 +151,31 @@ func displaySummary(s []string) error {
 
 	return nil
 }
+
+func displayGroupsSummary(g []_list.Group, by string) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	for _, x := range g {
+		k := x.Key
+		if k == "" {
+			k = "(none)"
+		}
+
+		h := fmt.Sprintf("%s: %s (%d)", by, k, len(x.Files))
+
+		fmt.Fprintln(tw, h)
+		fmt.Fprintln(tw, strings.Repeat("-", len(h)))
+
+		for _, t := range x.Files {
+			fmt.Fprintf(tw, "%s\n", t)
+		}
+
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+	fmt.Print(w.String())
+
+	return nil
+}
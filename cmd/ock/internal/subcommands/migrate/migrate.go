@@ -0,0 +1,150 @@
+// Package migrate implements the "migrate" subcommand.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/load"
+	"github.com/google/subcommands"
+
+	"github.com/slewiskelly/ock/internal/pkg/diff"
+	_migrate "github.com/slewiskelly/ock/internal/pkg/migrate"
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+	_set "github.com/slewiskelly/ock/internal/pkg/set"
+)
+
+// Migrate implements the "migrate" subcommand.
+type Migrate struct {
+	dryRun    bool
+	expr      string
+	glob      string
+	schema    string
+	transform string
+}
+
+// Name returns the name of the subcommand.
+func (*Migrate) Name() string {
+	return "migrate"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Migrate) Synopsis() string {
+	return "transforms the metadata of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Migrate) Usage() string {
+	return `ock migrate [flags] <path>
+
+The transformation is expressed in CUE, with each file's metadata being filled
+into the "in" field, and the "out" field becoming its replacement.
+
+Example transformation, renaming "lastReviewed" to "reviewed", and converting
+"tags" from a string to a list:
+
+  in: {...}
+  out: {
+  	for k, v in in if k != "lastReviewed" && k != "tags" {(k): v}
+  	if in.lastReviewed != _|_ {reviewed: in.lastReviewed}
+  	if in.tags != _|_ {tags: [...string] & ([in.tags] | in.tags)}
+  }
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (m *Migrate) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&m.dryRun, "dry-run", false, "display modifications without writing them")
+	f.StringVar(&m.expr, "e", "", "expression to filter files")
+	f.StringVar(&m.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&m.schema, "schema", ".schema.cue", "location of the schema file to validate against (empty to skip validation)")
+	f.StringVar(&m.transform, "t", "migration.cue", "location of the transformation file")
+}
+
+// Execute executes the subcommand.
+func (m *Migrate) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := m.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (m *Migrate) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	opts := []_migrate.Option{_migrate.DryRun(m.dryRun), _migrate.Expr(m.expr), _migrate.Glob(m.glob)}
+
+	// The default schema is optional, modified metadata otherwise not being
+	// validated.
+	if _, err := os.Stat(m.schema); m.schema == ".schema.cue" && errors.Is(err, os.ErrNotExist) {
+		m.schema = ""
+	}
+
+	if m.schema != "" {
+		v, err := _schema.Load(m.schema)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, _migrate.Schema(v))
+	}
+
+	i := load.Instances([]string{m.transform}, nil)[0]
+	if err := i.Err; err != nil {
+		return err
+	}
+
+	c, err := _migrate.Migrate(fs.Arg(0), cuecontext.New().BuildInstance(i), opts...)
+	if err != nil {
+		return err
+	}
+
+	return display(c)
+}
+
+func display(c []_set.Change) error {
+	var failed int
+
+	for _, x := range c {
+		if len(x.Errors) > 0 {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "%s: not modified\n", x.Name)
+
+			for _, e := range x.Errors {
+				if e.Field == "" {
+					fmt.Fprintf(os.Stderr, "\t%s\n", e.Message)
+				} else {
+					fmt.Fprintf(os.Stderr, "\t%s: %s\n", e.Field, e.Message)
+				}
+			}
+
+			continue
+		}
+
+		if !x.Written {
+			fmt.Print(string(diff.Unified(x.Name, x.Before, x.After)))
+			continue
+		}
+
+		fmt.Println(x.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) not modified due to errors", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// Package query implements the "query" subcommand.
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/google/subcommands"
+
+	_query "github.com/slewiskelly/ock/internal/pkg/query"
+)
+
+// Query implements the "query" subcommand.
+type Query struct {
+	expr   string
+	file   string
+	format string
+	glob   string
+}
+
+// Name returns the name of the subcommand.
+func (*Query) Name() string {
+	return "query"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Query) Synopsis() string {
+	return "evaluates a program against the metadata of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Query) Usage() string {
+	return `ock query [flags] <program> <path>
+ock query -file <program file> [flags] <path>
+
+The program is expressed in CUE, and is evaluated within a scope containing
+"files", a list of each file's name, start, end and metadata. Builtin packages
+may be used without being imported.
+
+Referencing a field which is not present in a file's metadata is an error, such
+references can be guarded with "if f.metadata.<field> != _|_".
+
+Examples:
+  ock query '[for f in files if f.metadata.status == "draft" {f.name}]' .
+  ock query 'list.Sort([for f in files {f.metadata.title}], list.Ascending)' .
+  ock query '{
+    let owners = {for f in files {(f.metadata.owner): _}}
+    for o, _ in owners {(o): len([for f in files if f.metadata.owner == o {f}])}
+  }' .
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (q *Query) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&q.expr, "e", "", "expression to filter files, prior to querying")
+	f.StringVar(&q.file, "file", "", "location of a file containing the program")
+	f.StringVar(&q.format, "f", "json", "display format (json | yaml)")
+	f.StringVar(&q.glob, "glob", "", "pattern to filter files, prior to querying")
+}
+
+// Execute executes the subcommand.
+func (q *Query) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	// A program and path, or only a path if the program is read from a file.
+	want := 2
+	if q.file != "" {
+		want = 1
+	}
+
+	if fs.NArg() < want {
+		fmt.Fprintf(os.Stderr, "No program or path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := q.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (q *Query) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	program, path := fs.Arg(0), fs.Arg(1)
+
+	if q.file != "" {
+		b, err := os.ReadFile(q.file)
+		if err != nil {
+			return err
+		}
+
+		program, path = string(b), fs.Arg(0)
+	}
+
+	v, err := _query.Query(path, program, _query.Expr(q.expr), _query.Glob(q.glob))
+	if err != nil {
+		return err
+	}
+
+	return display(v, q.format)
+}
+
+func display(v cue.Value, f string) error {
+	switch f {
+	case "json":
+		return displayJSON(v)
+	case "yaml":
+		return displayYAML(v)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayJSON(v cue.Value) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func displayYAML(v cue.Value) error {
+	b, err := yaml.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(b))
+
+	return nil
+}
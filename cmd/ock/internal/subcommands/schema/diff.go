@@ -0,0 +1,142 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/subcommands"
+
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+)
+
+// Diff implements the "schema diff" subcommand.
+type Diff struct {
+	format string
+	glob   string
+}
+
+// Name returns the name of the subcommand.
+func (*Diff) Name() string {
+	return "diff"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Diff) Synopsis() string {
+	return "compares two versions of the schema"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Diff) Usage() string {
+	return `ock schema diff [flags] <old> <new> [path]
+
+Reports the fields of #Metadata which were added, removed, tightened (allowing
+fewer values, or becoming required), loosened, or otherwise changed.
+
+If a path is given, files rooted at the path which would change from passing to
+failing validation (broken), and vice versa (fixed), are also reported.
+
+For example, to compare the schema with its previous revision:
+
+  git show HEAD~1:.schema.cue > /tmp/old.cue
+  ock schema diff /tmp/old.cue .schema.cue docs
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (d *Diff) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&d.format, "f", "summary", "display format (json | summary)")
+	f.StringVar(&d.glob, "glob", "", "pattern to filter files")
+}
+
+// Execute executes the subcommand.
+func (d *Diff) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "No schemas provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := d.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (d *Diff) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	a, err := _schema.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	b, err := _schema.Load(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	r, err := _schema.Diff(a, b, fs.Arg(2), _schema.Glob(d.glob))
+	if err != nil {
+		return err
+	}
+
+	switch d.format {
+	case "json":
+		return displayDeltaJSON(r)
+	case "summary":
+		return displayDeltaSummary(r)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayDeltaJSON(d *_schema.Delta) error {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func displayDeltaSummary(d *_schema.Delta) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	fmt.Fprintln(tw, "Field\tChange\tBefore\tAfter")
+	fmt.Fprintln(tw, "-----\t------\t------\t-----")
+
+	for _, f := range d.Fields {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Path, f.Change, f.Before, f.After)
+	}
+
+	tw.Flush()
+	fmt.Println(w.String())
+
+	for _, x := range []struct {
+		title string
+		files []_schema.Impact
+	}{{"Broken (passing, now failing)", d.Broken}, {"Fixed (failing, now passing)", d.Fixed}} {
+		fmt.Printf("%s: %d\n", x.title, len(x.files))
+
+		for _, f := range x.files {
+			fmt.Printf("  %s\n", f.Name)
+
+			for _, e := range f.Errors {
+				fmt.Printf("    %s\t%s\n", e.Field, strings.ReplaceAll(e.Message, "\n", "\n    \t"))
+			}
+		}
+	}
+
+	return nil
+}
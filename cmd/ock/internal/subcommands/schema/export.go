@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+)
+
+// Export implements the "schema export" subcommand.
+type Export struct {
+	format string
+	schema string
+}
+
+// Name returns the name of the subcommand.
+func (*Export) Name() string {
+	return "export"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Export) Synopsis() string {
+	return "exports the schema to another format"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Export) Usage() string {
+	return `ock schema export [flags]
+
+The #Metadata definition is converted into a JSON Schema (draft 2020-12), for
+use by editors, CMS forms, and other tools. Constraints which cannot be
+expressed in JSON Schema, such as bounds on strings, are omitted.
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (e *Export) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&e.format, "f", "jsonschema", "export format (jsonschema)")
+	f.StringVar(&e.schema, "schema", ".schema.cue", "location of the schema file to export")
+}
+
+// Execute executes the subcommand.
+func (e *Export) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	// TODO(slewiskelly): Validate flags.
+
+	if err := e.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (e *Export) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	if e.format != "jsonschema" {
+		return errors.New("unknown export format")
+	}
+
+	s, err := _schema.Load(e.schema)
+	if err != nil {
+		return err
+	}
+
+	b, err := _schema.JSONSchema(s)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/subcommands"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+)
+
+// Lint implements the "schema lint" subcommand.
+type Lint struct {
+	format string
+	schema string
+}
+
+// Name returns the name of the subcommand.
+func (*Lint) Name() string {
+	return "lint"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Lint) Synopsis() string {
+	return "checks the schema for mistakes"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Lint) Usage() string {
+	return `ock schema lint [flags]
+
+Reports errors for a missing #Metadata definition, definitions or fields which
+do not compile (such as empty disjunctions), fields with multiple, or invalid,
+severity attributes (@error, @warning, @notice, @info, or @severity), and
+fields with an invalid @path kind (exists, file, or dir), or @owner argument
+(none, or codeowners).
+
+Reports warnings for severity attributes which are empty, and for any attributes
+(severity, @path, @ref, or @owner) which would be ignored when validating, such
+as those attached to definitions or structs, rather than to the fields of
+#Metadata.
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (l *Lint) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&l.format, "f", "summary", "display format (json | summary)")
+	f.StringVar(&l.schema, "schema", ".schema.cue", "location of the schema file to lint")
+}
+
+// Execute executes the subcommand.
+func (l *Lint) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	// TODO(slewiskelly): Validate flags.
+
+	if err := l.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (l *Lint) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	s, err := _schema.Load(l.schema)
+	if err != nil {
+		return err
+	}
+
+	errs, wrns := _schema.Lint(s)
+
+	f := &report.File{Name: l.schema, Errors: errs, Warnings: wrns}
+
+	if err := display(f, l.format); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: invalid schema", l.schema)
+	}
+
+	return nil
+}
+
+func display(f *report.File, format string) error {
+	switch format {
+	case "json":
+		return displayJSON(f)
+	case "summary":
+		return displaySummary(f)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayJSON(f *report.File) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func displaySummary(f *report.File) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	for _, e := range f.Errors {
+		fmt.Fprintf(tw, "\033[38;2;255;0;0mERROR\033[0m\t%s\t%s\n", e.Field, e.Message)
+	}
+
+	for _, e := range f.Warnings {
+		fmt.Fprintf(tw, "\033[38;2;255;128;0mWARN\033[0m\t%s\t%s\n", e.Field, e.Message)
+	}
+
+	tw.Flush()
+	fmt.Print(w.String())
+	fmt.Printf("\033[38;2;255;0;0m%d errors\033[0m, \033[38;2;255;128;0m%d warnings\033[0m\n", len(f.Errors), len(f.Warnings))
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package schema implements the "schema" subcommand, and its own subcommands.
+package schema
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+)
+
+// Schema implements the "schema" subcommand.
+type Schema struct{}
+
+// Name returns the name of the subcommand.
+func (*Schema) Name() string {
+	return "schema"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Schema) Synopsis() string {
+	return "manages the schema"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Schema) Usage() string {
+	return `ock schema <subcommand> [flags]
+
+Subcommands:
+  diff      compares two versions of the schema
+  export    exports the schema to another format
+  lint      checks the schema for mistakes
+  test      tests the schema against example metadata
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (s *Schema) SetFlags(f *flag.FlagSet) {}
+
+// Execute executes the subcommand.
+func (s *Schema) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No subcommand provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	c := subcommands.NewCommander(fs, "ock schema")
+
+	c.Register(&Diff{}, "")
+	c.Register(&Export{}, "")
+	c.Register(&Lint{}, "")
+	c.Register(&Test{}, "")
+	c.Register(c.HelpCommand(), "")
+
+	return c.Execute(ctx, args...)
+}
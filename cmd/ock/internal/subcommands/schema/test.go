@@ -0,0 +1,119 @@
+package schema
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+)
+
+// Test implements the "schema test" subcommand.
+type Test struct {
+	dir    string
+	schema string
+}
+
+// Name returns the name of the subcommand.
+func (*Test) Name() string {
+	return "test"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Test) Synopsis() string {
+	return "tests the schema against example metadata"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Test) Usage() string {
+	return `ock schema test [flags]
+
+Validates fixtures, YAML files containing example metadata, against the schema,
+and compares the findings with those expected. Fixtures are read from the
+directory next to the schema file, named after it (.schema.test, by default).
+
+Fixtures may also be markdown files, whose frontmatter is the example metadata,
+and whose body is provided to the schema's _body field. Markdown files without
+frontmatter, such as a README, are skipped.
+
+Expected findings are declared by comments within each fixture's metadata, of
+the form:
+
+  # <severity>: <field> [message]
+
+Where severity is the name (error, warning, notice, or info), or number, of the
+finding's level.
+
+A fixture passes if exactly the expected findings are reported, with messages
+only being compared if given. For example:
+
+  # error: status
+  # warning: tags must contain at least one tag
+  title: Example
+  status: unknown
+  tags: []
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (t *Test) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.dir, "dir", "", "location of the fixtures (default: the schema filename, with the .test extension)")
+	f.StringVar(&t.schema, "schema", ".schema.cue", "location of the schema file to test")
+}
+
+// Execute executes the subcommand.
+func (t *Test) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	// TODO(slewiskelly): Validate flags.
+
+	if err := t.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (t *Test) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	if t.dir == "" {
+		t.dir = strings.TrimSuffix(t.schema, ".cue") + ".test"
+	}
+
+	s, err := _schema.Load(t.schema)
+	if err != nil {
+		return err
+	}
+
+	r, err := _schema.Test(s, t.dir)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+
+	for _, x := range r {
+		if x.Passed() {
+			fmt.Printf("\033[38;2;0;192;0mPASS\033[0m\t%s\n", x.Name)
+			continue
+		}
+
+		failed++
+
+		fmt.Printf("\033[38;2;255;0;0mFAIL\033[0m\t%s\n", x.Name)
+
+		for _, f := range x.Failures {
+			fmt.Printf("\t%s\n", strings.ReplaceAll(f, "\n", "\n\t\t"))
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", len(r)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d fixture(s) failed", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package set implements the "set" and "unset" subcommands.
+package set
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	"github.com/slewiskelly/ock/internal/pkg/diff"
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+	_set "github.com/slewiskelly/ock/internal/pkg/set"
+)
+
+// Set implements the "set" subcommand.
+type Set struct {
+	dryRun bool
+	expr   string
+	glob   string
+	schema string
+}
+
+// Name returns the name of the subcommand.
+func (*Set) Name() string {
+	return "set"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Set) Synopsis() string {
+	return "sets metadata fields of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Set) Usage() string {
+	return `ock set [flags] <path> <field>=<value>...
+
+Values are expressed in YAML, nested fields are separated by ".".
+
+Example:
+  ock set -e 'reviewed < "2024-01-01"' docs status=archived
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (s *Set) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&s.dryRun, "dry-run", false, "display modifications without writing them")
+	f.StringVar(&s.expr, "e", "", "expression to filter files")
+	f.StringVar(&s.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&s.schema, "schema", ".schema.cue", "location of the schema file to validate against (empty to skip validation)")
+}
+
+// Execute executes the subcommand.
+func (s *Set) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "No path or fields provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := s.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (s *Set) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	var fields []_set.Field
+
+	for _, a := range fs.Args()[1:] {
+		p, v, ok := strings.Cut(a, "=")
+		if !ok || p == "" {
+			return fmt.Errorf("invalid field %q, expected <field>=<value>", a)
+		}
+
+		fields = append(fields, _set.Field{Path: p, Value: v})
+	}
+
+	opts, err := options(s.expr, s.glob, s.schema, s.dryRun)
+	if err != nil {
+		return err
+	}
+
+	c, err := _set.Set(fs.Arg(0), fields, opts...)
+	if err != nil {
+		return err
+	}
+
+	return display(c)
+}
+
+func options(expr, glob, schema string, dryRun bool) ([]_set.Option, error) {
+	opts := []_set.Option{_set.DryRun(dryRun), _set.Expr(expr), _set.Glob(glob)}
+
+	// The default schema is optional, modified metadata otherwise not being
+	// validated.
+	if _, err := os.Stat(schema); schema == "" || schema == ".schema.cue" && errors.Is(err, os.ErrNotExist) {
+		return opts, nil
+	}
+
+	v, err := _schema.Load(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, _set.Schema(v)), nil
+}
+
+func display(c []_set.Change) error {
+	var failed int
+
+	for _, x := range c {
+		if len(x.Errors) > 0 {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "%s: not modified\n", x.Name)
+
+			for _, e := range x.Errors {
+				if e.Field == "" {
+					fmt.Fprintf(os.Stderr, "\t%s\n", e.Message)
+				} else {
+					fmt.Fprintf(os.Stderr, "\t%s: %s\n", e.Field, e.Message)
+				}
+			}
+
+			continue
+		}
+
+		if !x.Written {
+			fmt.Print(string(diff.Unified(x.Name, x.Before, x.After)))
+			continue
+		}
+
+		fmt.Println(x.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) not modified due to errors", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package set
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+
+	_set "github.com/slewiskelly/ock/internal/pkg/set"
+)
+
+// Unset implements the "unset" subcommand.
+type Unset struct {
+	dryRun bool
+	expr   string
+	glob   string
+	schema string
+}
+
+// Name returns the name of the subcommand.
+func (*Unset) Name() string {
+	return "unset"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Unset) Synopsis() string {
+	return "removes metadata fields of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Unset) Usage() string {
+	return `ock unset [flags] <path> <field>...
+
+Nested fields are separated by ".".
+
+Example:
+  ock unset -e 'status == "archived"' docs reviewed
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (u *Unset) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&u.dryRun, "dry-run", false, "display modifications without writing them")
+	f.StringVar(&u.expr, "e", "", "expression to filter files")
+	f.StringVar(&u.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&u.schema, "schema", ".schema.cue", "location of the schema file to validate against (empty to skip validation)")
+}
+
+// Execute executes the subcommand.
+func (u *Unset) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "No path or fields provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := u.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (u *Unset) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	opts, err := options(u.expr, u.glob, u.schema, u.dryRun)
+	if err != nil {
+		return err
+	}
+
+	c, err := _set.Unset(fs.Arg(0), fs.Args()[1:], opts...)
+	if err != nil {
+		return err
+	}
+
+	return display(c)
+}
@@ -0,0 +1,198 @@
+// Package stale implements the "stale" subcommand.
+package stale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	_stale "github.com/slewiskelly/ock/internal/pkg/stale"
+)
+
+// Stale implements the "stale" subcommand.
+type Stale struct {
+	age    string
+	expr   string
+	field  string
+	format string
+	glob   string
+	now    string
+	owner  string
+}
+
+// Name returns the name of the subcommand.
+func (*Stale) Name() string {
+	return "stale"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Stale) Synopsis() string {
+	return "reports file(s) under a given path which are due for review"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Stale) Usage() string {
+	return `ock stale [flags] <path>
+
+Files which were last reviewed longer ago than the given age, or which have no
+review date, are reported, grouped by owner.
+
+Ages are expressed as Go durations, with the addition of days (d) and weeks (w),
+for example: 90d, 26w.
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (s *Stale) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&s.age, "age", "180d", "maximum age of a review")
+	f.StringVar(&s.expr, "e", "", "expression to filter files")
+	f.StringVar(&s.field, "field", "", `field containing the review date (default "reviewed", falling back to "lastReviewed")`)
+	f.StringVar(&s.format, "f", "summary", "display format (json | summary)")
+	f.StringVar(&s.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&s.now, "now", "", "date to compare review dates against, in RFC3339 format (default current time)")
+	f.StringVar(&s.owner, "owner", "owner", "field containing the owner")
+}
+
+// Execute executes the subcommand.
+func (s *Stale) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := s.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (s *Stale) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	age, err := parseAge(s.age)
+	if err != nil {
+		return err
+	}
+
+	opts := []_stale.Option{_stale.Expr(s.expr), _stale.Glob(s.glob), _stale.Owner(s.owner)}
+
+	if s.field != "" {
+		opts = append(opts, _stale.Field(s.field))
+	}
+
+	if s.now != "" {
+		now, err := parseTime(s.now)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, _stale.Now(now))
+	}
+
+	g, err := _stale.Stale(fs.Arg(0), age, opts...)
+	if err != nil {
+		return err
+	}
+
+	return display(g, s.format)
+}
+
+func display(g []_stale.Group, f string) error {
+	switch f {
+	case "json":
+		return displayJSON(g)
+	case "summary":
+		return displaySummary(g)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayJSON(g []_stale.Group) error {
+	b, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func displaySummary(g []_stale.Group) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	var n int
+
+	for _, x := range g {
+		owner := x.Owner
+		if owner == "" {
+			owner = "(unowned)"
+		}
+
+		fmt.Fprintf(tw, "%s\n", owner)
+
+		for _, f := range x.Files {
+			n++
+
+			if f.Reviewed == "" {
+				fmt.Fprintf(tw, "%s\tnever reviewed\n", f.Name)
+				continue
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%d days ago\n", f.Name, f.Reviewed, f.Days)
+		}
+
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+	fmt.Print(w.String())
+	fmt.Printf("%d stale files\n", n)
+
+	return nil
+}
+
+// parseAge parses a duration, additionally supporting days (d) and weeks (w).
+func parseAge(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			i, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+
+			return time.Duration(i) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+
+	return d, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	t, ok := fields.Date(s)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,35 @@
+package stale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Duration
+		err  bool
+	}{
+		{in: "90d", want: 90 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "d", err: true},
+		{in: "ninety", err: true},
+	} {
+		got, err := parseAge(tc.in)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("parseAge(%q) = %v, %v, want %v, error: %v", tc.in, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	if got, err := parseTime("2024-05-31"); err != nil || !got.Equal(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseTime() = %v, %v", got, err)
+	}
+
+	if _, err := parseTime("tomorrow"); err == nil {
+		t.Errorf("parseTime(%q) = nil, want error", "tomorrow")
+	}
+}
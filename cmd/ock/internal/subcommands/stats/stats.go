@@ -0,0 +1,186 @@
+// Package stats implements the "stats" subcommand.
+package stats
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/subcommands"
+
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
+	_stats "github.com/slewiskelly/ock/internal/pkg/stats"
+)
+
+// Stats implements the "stats" subcommand.
+type Stats struct {
+	expr   string
+	format string
+	glob   string
+	schema string
+	top    int
+}
+
+// Name returns the name of the subcommand.
+func (*Stats) Name() string {
+	return "stats"
+}
+
+// Synopsis returns a one-line summary of the subcommand.
+func (*Stats) Synopsis() string {
+	return "aggregates the metadata of file(s) under a given path"
+}
+
+// Usage returns a longer explanation and/or usage example(s) of the subcommand.
+func (*Stats) Usage() string {
+	return `ock stats [flags] <path>
+
+Reports, for each field, the number of files in which it is present and
+missing, the earliest and latest values of date fields, and the occurrences of
+each value (or list element).
+
+The table format displays the occurrences of each field's most common values,
+limited by -top.
+`
+}
+
+// SetFlags sets the flags specific to the subcommand.
+func (s *Stats) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&s.expr, "e", "", "expression to filter files")
+	f.StringVar(&s.format, "f", "table", "display format (csv | json | table)")
+	f.StringVar(&s.glob, "glob", "", "pattern to filter files")
+	f.StringVar(&s.schema, "schema", "", "location of a schema file, whose fields are always reported on")
+	f.IntVar(&s.top, "top", 10, "maximum number of values to display per field, in the table format (0 for all)")
+}
+
+// Execute executes the subcommand.
+func (s *Stats) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "No path provided.\n\nUsage: ")
+		fs.Usage()
+		return subcommands.ExitUsageError
+	}
+
+	// TODO(slewiskelly): Validate flags.
+
+	if err := s.execute(ctx, fs, args...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (s *Stats) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
+	opts := []_stats.Option{_stats.Expr(s.expr), _stats.Glob(s.glob)}
+
+	if s.schema != "" {
+		v, err := _schema.Load(s.schema)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, _stats.Schema(v))
+	}
+
+	r, err := _stats.Stats(fs.Arg(0), opts...)
+	if err != nil {
+		return err
+	}
+
+	return display(r, s.format, s.top)
+}
+
+func display(s *_stats.Summary, f string, top int) error {
+	switch f {
+	case "csv":
+		return displayCSV(s)
+	case "json":
+		return displayJSON(s)
+	case "table":
+		return displayTable(s, top)
+	default:
+		return errors.New("unknown output format")
+	}
+}
+
+func displayCSV(s *_stats.Summary) error {
+	w := csv.NewWriter(os.Stdout)
+
+	w.Write([]string{"field", "present", "missing", "min", "max", "value", "count"})
+
+	for _, f := range s.Fields {
+		row := []string{f.Path, strconv.Itoa(f.Present), strconv.Itoa(f.Missing), f.Min, f.Max}
+
+		if len(f.Values) == 0 {
+			w.Write(append(row, "", ""))
+		}
+
+		for _, v := range f.Values {
+			w.Write(append(row, v.Value, strconv.Itoa(v.Count)))
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func displayJSON(s *_stats.Summary) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func displayTable(s *_stats.Summary, top int) error {
+	w := new(strings.Builder)
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+
+	fmt.Fprintf(tw, "Files\t%d\n\n", s.Files)
+
+	fmt.Fprintln(tw, "Field\tPresent\tMissing\tMin\tMax")
+	fmt.Fprintln(tw, "-----\t-------\t-------\t---\t---")
+
+	for _, f := range s.Fields {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", f.Path, f.Present, f.Missing, f.Min, f.Max)
+	}
+
+	for _, f := range s.Fields {
+		if len(f.Values) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(tw, "\n%s\tCount\n", f.Path)
+		fmt.Fprintf(tw, "%s\t-----\n", strings.Repeat("-", len(f.Path)))
+
+		v := f.Values
+		if top > 0 && len(v) > top {
+			v = v[:top]
+		}
+
+		for _, x := range v {
+			fmt.Fprintf(tw, "%s\t%d\n", x.Value, x.Count)
+		}
+
+		if n := len(f.Values) - len(v); n > 0 {
+			fmt.Fprintf(tw, "(%d more)\t\n", n)
+		}
+	}
+
+	tw.Flush()
+	fmt.Println(w.String())
+
+	return nil
+}
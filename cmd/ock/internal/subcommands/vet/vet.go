@@ -8,24 +8,30 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
-	"cuelang.org/go/cue/cuecontext"
-	"cuelang.org/go/cue/load"
 	"github.com/google/subcommands"
 
 	"github.com/slewiskelly/ock/internal/pkg/report"
+	_schema "github.com/slewiskelly/ock/internal/pkg/schema"
 	_vet "github.com/slewiskelly/ock/internal/pkg/vet"
 )
 
 // Vet implements the "vet" subcommand.
 type Vet struct {
-	def    string
-	format string
-	glob   string
-	lvl    string
-	schema string
+	baseline string
+	def      string
+	fail     string
+	format   string
+	glob     string
+	lvl      string
+	missing  []string
+	owners   string
+	schema   string
+	stale    bool
+	write    string
 }
 
 // Name returns the name of the subcommand.
@@ -41,15 +47,46 @@ func (*Vet) Synopsis() string {
 // Usage returns a longer explanation and/or usage example(s) of the subcommand.
 func (*Vet) Usage() string {
 	return `ock vet [flags] <path>
+
+Findings of a field may be suppressed by a file, either by listing the field
+under its ock-ignore key, or by a comment within its frontmatter:
+
+  # ock:ignore <field>... [reason=<reason>]
+
+Findings of existing files can be recorded to a baseline, with subsequent
+validation reporting only new findings:
+
+  ock vet -write-baseline .ock-baseline.json <path>
+  ock vet -baseline .ock-baseline.json <path>
+
+Files without frontmatter are ignored, unless a policy (ignore, or a level) is
+given for paths matching a pattern, with later policies taking precedence:
+
+  ock vet -missing error -missing 'docs/drafts/**=ignore' <path>
+
+By default, vet exits successfully regardless of findings. To fail, for example
+in CI, if any file has findings at, or above, a level (including stale
+suppressions, which are errors):
+
+  ock vet -fail-on error -stale-suppressions <path>
 `
 }
 
 // SetFlags sets the flags specific to the subcommand.
 func (v *Vet) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&v.baseline, "baseline", "", "location of a baseline file, whose recorded findings are not reported")
 	f.StringVar(&v.format, "f", "summary", "display format (json | summary)")
-	f.StringVar(&v.lvl, "l", "warn", "minimum error level to display (error | warn)")
+	f.StringVar(&v.fail, "fail-on", "", "minimum level of findings which cause a failure exit status (error | warn | notice | info, or a number); never if empty")
+	f.StringVar(&v.lvl, "l", "warn", "minimum error level to display (error | warn | notice | info, or a number)")
 	f.StringVar(&v.glob, "glob", "", "pattern to filter files")
-	f.StringVar(&v.schema, "schema", ".schema.cue", "location of the schema file to validate against")
+	f.Func("missing", "policy for files without frontmatter, optionally for paths matching a pattern ([pattern=]ignore | error | warn | notice | info, or a number); may be repeated", func(s string) error {
+		v.missing = append(v.missing, s)
+		return nil
+	})
+	f.StringVar(&v.owners, "owners", "", "location of a CODEOWNERS file, or YAML or CSV roster, to check @owner fields against")
+	f.StringVar(&v.schema, "schema", ".schema.cue", "location of the schema file to validate against (CUE, JSON Schema, or OpenAPI)")
+	f.BoolVar(&v.stale, "stale-suppressions", false, "report suppressions of fields without findings as errors")
+	f.StringVar(&v.write, "write-baseline", "", "location to record current findings to, as a baseline, instead of reporting them")
 }
 
 // Execute executes the subcommand.
@@ -71,21 +108,95 @@ func (v *Vet) Execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}
 }
 
 func (v *Vet) execute(ctx context.Context, fs *flag.FlagSet, args ...interface{}) error {
-	i := load.Instances([]string{v.schema}, nil)[0]
-	if err := i.Err; err != nil {
+	l, err := _vet.ParseLvl(v.lvl)
+	if err != nil {
 		return err
 	}
 
-	r, err := _vet.Vet(fs.Arg(0), cuecontext.New().BuildInstance(i), _vet.Glob(v.glob), _vet.Level(lvlFrom(v.lvl)))
+	var fail _vet.Lvl
+
+	if v.fail != "" {
+		if fail, err = _vet.ParseLvl(v.fail); err != nil {
+			return fmt.Errorf("invalid failure level: %w", err)
+		}
+	}
+
+	s, err := _schema.Load(v.schema)
 	if err != nil {
 		return err
 	}
 
+	opts := []_vet.Option{_vet.Glob(v.glob), _vet.Level(l), _vet.StaleSuppressions(v.stale)}
+
+	switch {
+	case v.owners != "":
+		opts = append(opts, _vet.Owners(v.owners))
+	case _vet.HasOwners(s):
+		fmt.Fprintln(os.Stderr, "warning: fields with the @owner attribute are not checked, specify -owners to check them")
+	}
+
+	for _, m := range v.missing {
+		i := strings.LastIndex(m, "=")
+
+		if m[i+1:] == "ignore" {
+			opts = append(opts, _vet.Missing(m[:max(i, 0)], 0))
+			continue
+		}
+
+		l, err := _vet.ParseLvl(m[i+1:])
+		if err != nil {
+			return fmt.Errorf("invalid missing frontmatter policy: %w", err)
+		}
+
+		opts = append(opts, _vet.Missing(m[:max(i, 0)], l))
+	}
+
+	// Baselines record all current findings, including those of any existing
+	// baseline.
+	if v.baseline != "" && v.write == "" {
+		opts = append(opts, _vet.Baseline(v.baseline))
+	}
+
+	r, err := _vet.Vet(fs.Arg(0), s, opts...)
+	if err != nil {
+		return err
+	}
+
+	if v.write != "" {
+		return _vet.WriteBaseline(v.write, r)
+	}
+
 	if len(r) < 1 {
 		return nil
 	}
 
-	return display(r, v.format)
+	if err := display(r, v.format); err != nil {
+		return err
+	}
+
+	if n := failed(r, fail); n > 0 {
+		return fmt.Errorf("%d file(s) failed validation", n)
+	}
+
+	return nil
+}
+
+// failed returns the number of files with findings at, or above, the given
+// level, or 0 if the level is 0.
+func failed(r report.Report, l _vet.Lvl) int {
+	if l == 0 {
+		return 0
+	}
+
+	var n int
+
+	for _, f := range r {
+		if slices.ContainsFunc(f.Findings, func(x report.Finding) bool { return _vet.Lvl(x.Level) >= l }) {
+			n++
+		}
+	}
+
+	return n
 }
 
 func display(r report.Report, f string) error {
@@ -114,41 +225,76 @@ func displaySummary(r report.Report) error {
 	w := new(strings.Builder)
 	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
 
-	var ec, wc int
+	var ec, wc, nc, ic, sc int
 
 	for _, x := range r {
-		if len(x.Errors) > 0 || len(x.Warnings) > 0 {
-			ec += len(x.Errors)
-			wc += len(x.Warnings)
+		if len(x.Findings) < 1 && len(x.Suppressed) < 1 {
+			continue
+		}
 
+		// Files without frontmatter have no range to display.
+		if x.End > 0 {
 			fmt.Fprintf(tw, "%s (%d-%d)\n", x.Name, x.Start, x.End)
+		} else {
+			fmt.Fprintln(tw, x.Name)
+		}
 
-			for _, e := range x.Errors {
-				fmt.Fprintf(tw, "\033[38;2;255;0;0mERROR\033[0m\t%s\t%s\n", e.Field, e.Message)
+		for _, f := range x.Findings {
+			switch l := _vet.Lvl(f.Level); {
+			case l >= _vet.LvlError:
+				ec++
+			case l >= _vet.LvlWarn:
+				wc++
+			case l >= _vet.LvlNotice:
+				nc++
+			default:
+				ic++
 			}
 
-			for _, e := range x.Warnings {
-				fmt.Fprintf(tw, "\033[38;2;255;128;0mWARN\033[0m\t%s\t%s\n", e.Field, e.Message)
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", label(_vet.Lvl(f.Level)), f.Field, f.Message)
+		}
+
+		for _, f := range x.Suppressed {
+			sc++
+
+			msg := f.Message
+			if f.Reason != "" {
+				msg = fmt.Sprintf("%s (%s)", msg, f.Reason)
 			}
 
-			fmt.Fprintln(tw)
+			fmt.Fprintf(tw, "\033[38;2;128;128;128mSUPPRESSED\033[0m\t%s\t%s\n", f.Field, msg)
 		}
+
+		fmt.Fprintln(tw)
 	}
 
 	tw.Flush()
 	fmt.Println(w.String())
-	fmt.Printf("\033[38;2;255;0;0m%d errors\033[0m, \033[38;2;255;128;0m%d warnings\033[0m\n", ec, wc)
+	fmt.Printf("\033[38;2;255;0;0m%d errors\033[0m, \033[38;2;255;128;0m%d warnings\033[0m, \033[38;2;0;128;255m%d notices\033[0m, %d info, \033[38;2;128;128;128m%d suppressed\033[0m\n", ec, wc, nc, ic, sc)
 
 	return nil
 }
 
-func lvlFrom(s string) _vet.Lvl {
-	switch strings.ToLower(s) {
-	case "err", "error":
-		return _vet.LvlError
-	case "warn", "warning":
-		fallthrough
+// label returns the colored label of a level. Levels without a name are
+// colored according to the nearest named level below.
+func label(l _vet.Lvl) string {
+	s := strings.ToUpper(l.String())
+
+	switch {
+	case l == _vet.LvlWarn:
+		s = "WARN"
+	case l != _vet.LvlError && l != _vet.LvlNotice && l != _vet.LvlInfo:
+		s = "LVL" + s
+	}
+
+	switch {
+	case l >= _vet.LvlError:
+		return "\033[38;2;255;0;0m" + s + "\033[0m"
+	case l >= _vet.LvlWarn:
+		return "\033[38;2;255;128;0m" + s + "\033[0m"
+	case l >= _vet.LvlNotice:
+		return "\033[38;2;0;128;255m" + s + "\033[0m"
 	default:
-		return _vet.LvlWarn
+		return s
 	}
 }
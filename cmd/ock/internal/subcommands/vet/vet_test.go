@@ -0,0 +1,35 @@
+package vet
+
+import (
+	"testing"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+	_vet "github.com/slewiskelly/ock/internal/pkg/vet"
+)
+
+func TestFailed(t *testing.T) {
+	f := func(l _vet.Lvl) report.Finding {
+		return report.Finding{Severity: l.String(), Level: int(l)}
+	}
+
+	r := report.Report{
+		{Name: "a.md", Findings: []report.Finding{f(_vet.LvlError), f(_vet.LvlWarn)}},
+		{Name: "b.md", Findings: []report.Finding{f(_vet.LvlWarn)}},
+		{Name: "c.md", Findings: []report.Finding{f(_vet.LvlInfo)}},
+		{Name: "d.md", Suppressed: []report.Finding{f(_vet.LvlError)}},
+	}
+
+	for _, tc := range []struct {
+		lvl  _vet.Lvl
+		want int
+	}{
+		{0, 0},
+		{_vet.LvlError, 1},
+		{_vet.LvlWarn, 2},
+		{_vet.LvlInfo, 3},
+	} {
+		if got := failed(r, tc.lvl); got != tc.want {
+			t.Errorf("failed(%v) = %d, want %d", tc.lvl, got, tc.want)
+		}
+	}
+}
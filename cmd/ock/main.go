@@ -7,17 +7,34 @@ import (
 
 	"github.com/google/subcommands"
 
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/export"
 	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/get"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/index"
 	ini "github.com/slewiskelly/ock/cmd/ock/internal/subcommands/init"
 	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/list"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/migrate"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/query"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/schema"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/set"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/stale"
+	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/stats"
 	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/version"
 	"github.com/slewiskelly/ock/cmd/ock/internal/subcommands/vet"
 )
 
 func init() {
+	subcommands.Register(&export.Export{}, "")
 	subcommands.Register(&get.Get{}, "")
+	subcommands.Register(&index.Index{}, "")
 	subcommands.Register(&ini.Init{}, "")
 	subcommands.Register(&list.List{}, "")
+	subcommands.Register(&migrate.Migrate{}, "")
+	subcommands.Register(&query.Query{}, "")
+	subcommands.Register(&schema.Schema{}, "")
+	subcommands.Register(&set.Set{}, "")
+	subcommands.Register(&set.Unset{}, "")
+	subcommands.Register(&stale.Stale{}, "")
+	subcommands.Register(&stats.Stats{}, "")
 	subcommands.Register(&version.Version{}, "")
 	subcommands.Register(&vet.Vet{}, "")
 
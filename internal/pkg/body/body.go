@@ -0,0 +1,89 @@
+// Package body provides functionality to derive facts from a document's body.
+package body
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Facts represents the facts derived from a (markdown) body.
+type Facts struct {
+	Headings  []Heading `json:"headings"`  // ATX headings, in order of appearance.
+	H1        string    `json:"h1"`        // Text of the first level 1 heading, if any.
+	Words     int       `json:"words"`     // Number of words, excluding code blocks.
+	Links     []string  `json:"links"`     // Destinations of links and images, in order of appearance.
+	Languages []string  `json:"languages"` // Languages of fenced code blocks, in order of appearance.
+	Text      string    `json:"text"`      // Body itself.
+}
+
+// Heading represents an individual heading.
+type Heading struct {
+	Level int    `json:"level"` // Level of the heading, from 1 to 6.
+	Text  string `json:"text"`  // Text of the heading.
+}
+
+var (
+	heading = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	fence   = regexp.MustCompile("^ {0,3}(```+|~~~+)\\s*([^\\s`]*)")
+	link    = regexp.MustCompile(`\]\(\s*<?([^\s)>]+)>?(?:\s+"[^"]*")?\s*\)|<(https?://[^>\s]+)>`)
+)
+
+// Parse derives facts from the given body.
+//
+// Only ATX headings (# Heading) are recognized, and inline code is counted as
+// words, and may contain links.
+func Parse(b []byte) *Facts {
+	f := &Facts{
+		Headings:  []Heading{},
+		Links:     []string{},
+		Languages: []string{},
+		Text:      string(b),
+	}
+
+	var delim string // Delimiter of the current fenced code block, if any.
+
+	for s := bufio.NewScanner(bytes.NewReader(b)); s.Scan(); {
+		l := s.Text()
+
+		if m := fence.FindStringSubmatch(l); m != nil {
+			switch {
+			case delim == "":
+				delim = m[1]
+
+				if m[2] != "" {
+					f.Languages = append(f.Languages, m[2])
+				}
+
+				continue
+			case m[1][0] == delim[0] && len(m[1]) >= len(delim) && strings.TrimSpace(l[strings.Index(l, m[1])+len(m[1]):]) == "":
+				delim = ""
+				continue
+			}
+		}
+
+		if delim != "" {
+			continue
+		}
+
+		if m := heading.FindStringSubmatch(l); m != nil {
+			h := Heading{Level: len(m[1]), Text: m[2]}
+
+			if h.Level == 1 && f.H1 == "" {
+				f.H1 = h.Text
+			}
+
+			f.Headings = append(f.Headings, h)
+			l = h.Text
+		}
+
+		for _, m := range link.FindAllStringSubmatch(l, -1) {
+			f.Links = append(f.Links, m[1]+m[2])
+		}
+
+		f.Words += len(strings.Fields(l))
+	}
+
+	return f
+}
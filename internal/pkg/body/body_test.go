@@ -0,0 +1,80 @@
+package body
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want Facts // Text is that of the body.
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "headings",
+			body: "# Title\n\nIntro.\n\n## Usage ##\n\n###### Deep\n#NotAHeading\n    # Indented\n# Second\n#\n",
+			want: Facts{
+				Headings: []Heading{{1, "Title"}, {2, "Usage"}, {6, "Deep"}, {1, "Second"}, {1, ""}},
+				H1:       "Title",
+				Words:    8,
+			},
+		},
+		{
+			name: "links",
+			body: "See [docs](docs/a.md \"Docs\") and ![diagram](</images/x.png>).\nAlso <https://example.com> and [anchor](#usage).\n",
+			want: Facts{
+				Words: 9, // Including the title of the link.
+				Links: []string{"docs/a.md", "/images/x.png", "https://example.com", "#usage"},
+			},
+		},
+		{
+			name: "heading links",
+			body: "## [Related](related.md)\n",
+			want: Facts{
+				Headings: []Heading{{2, "[Related](related.md)"}},
+				Words:    1,
+				Links:    []string{"related.md"},
+			},
+		},
+		{
+			name: "code blocks",
+			body: "Before.\n\n```go\n# Not a heading\n[not](a-link.md)\n```\n\n~~~\nplain\n~~~\n\n````md\n```\nnested\n```\n````\n\nAfter `inline [code](code.md)`.\n",
+			want: Facts{
+				Words:     4,
+				Links:     []string{"code.md"},
+				Languages: []string{"go", "md"},
+			},
+		},
+		{
+			name: "unclosed code block",
+			body: "Before.\n\n```sh\necho words\n",
+			want: Facts{
+				Words:     1,
+				Languages: []string{"sh"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.want
+			want.Text = tc.body
+
+			for _, s := range []*[]string{&want.Links, &want.Languages} {
+				if *s == nil {
+					*s = []string{}
+				}
+			}
+
+			if want.Headings == nil {
+				want.Headings = []Heading{}
+			}
+
+			if got := Parse([]byte(tc.body)); !reflect.DeepEqual(*got, want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tc.body, *got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package diff provides functionality to compare the contents of files.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// context is the number of unchanged lines surrounding each change.
+const context = 3
+
+// Unified returns the differences between a and b, in unified format, or nil
+// if they are equal.
+func Unified(name string, a, b []byte) []byte {
+	if bytes.Equal(a, b) {
+		return nil
+	}
+
+	e := compare(lines(a), lines(b))
+
+	w := new(bytes.Buffer)
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", name, name)
+
+	for i := 0; i < len(e); {
+		for i < len(e) && e[i].kind == ' ' {
+			i++
+		}
+
+		if i == len(e) {
+			break
+		}
+
+		start, end := max(i-context, 0), i
+
+		for {
+			for end < len(e) && e[end].kind != ' ' {
+				end++
+			}
+
+			j := end
+			for j < len(e) && e[j].kind == ' ' {
+				j++
+			}
+
+			if j == len(e) || j-end > 2*context {
+				end = min(end+context, len(e))
+				break
+			}
+
+			end = j
+		}
+
+		hunk(w, e[start:end])
+
+		i = end
+	}
+
+	return w.Bytes()
+}
+
+type edit struct {
+	kind byte   // One of ' ', '-', or '+'.
+	line []byte // Line, including any line terminator.
+	a, b int    // Line numbers (0-indexed) preceding the edit.
+}
+
+func hunk(w *bytes.Buffer, e []edit) {
+	var na, nb int
+
+	for _, x := range e {
+		if x.kind != '+' {
+			na++
+		}
+		if x.kind != '-' {
+			nb++
+		}
+	}
+
+	sa, sb := e[0].a, e[0].b
+	if na > 0 {
+		sa++
+	}
+	if nb > 0 {
+		sb++
+	}
+
+	fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", sa, na, sb, nb)
+
+	for _, x := range e {
+		w.WriteByte(x.kind)
+		w.Write(x.line)
+
+		if !bytes.HasSuffix(x.line, []byte("\n")) {
+			w.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// compare computes the edits required to transform a into b.
+//
+// Common leading and trailing lines are trimmed before computing the longest
+// common subsequence of the remainder, as changes are typically localized.
+func compare(a, b [][]byte) []edit {
+	var p, s int
+
+	for p < len(a) && p < len(b) && bytes.Equal(a[p], b[p]) {
+		p++
+	}
+
+	for s < len(a)-p && s < len(b)-p && bytes.Equal(a[len(a)-1-s], b[len(b)-1-s]) {
+		s++
+	}
+
+	x, y := a[p:len(a)-s], b[p:len(b)-s]
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and
+	// y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if bytes.Equal(x[i], y[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var e []edit
+
+	for i := 0; i < p; i++ {
+		e = append(e, edit{' ', a[i], i, i})
+	}
+
+	i, j := 0, 0
+
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && bytes.Equal(x[i], y[j]):
+			e = append(e, edit{' ', x[i], p + i, p + j})
+			i, j = i+1, j+1
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			e = append(e, edit{'-', x[i], p + i, p + j})
+			i++
+		default:
+			e = append(e, edit{'+', y[j], p + i, p + j})
+			j++
+		}
+	}
+
+	for k := 0; k < s; k++ {
+		e = append(e, edit{' ', a[len(a)-s+k], len(a) - s + k, len(b) - s + k})
+	}
+
+	return e
+}
+
+func lines(b []byte) [][]byte {
+	var l [][]byte
+
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n') + 1
+		if i == 0 {
+			i = len(b)
+		}
+
+		l, b = append(l, b[:i]), b[i:]
+	}
+
+	return l
+}
@@ -0,0 +1,289 @@
+// Package export provides functionality to export document metadata.
+package export
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	_ "modernc.org/sqlite" // Registers the "sqlite" driver.
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	"github.com/slewiskelly/ock/internal/pkg/get"
+)
+
+// column represents a metadata field, and its corresponding column, or table
+// in the case of lists.
+type column struct {
+	name string   // Name of the column, or table in the case of lists.
+	path cue.Path // Path of the field.
+	typ  string   // SQLite type of the column, or of list elements.
+	list bool     // Whether the field is a list, normalized into its own table.
+}
+
+// SQLite exports the metadata of (markdown) files rooted at the given path, to
+// the SQLite database with the given filename.
+//
+// Metadata is exported to a "documents" table, containing the name, start and
+// end of each file, along with a "meta_<field>" column for each (non-list)
+// field, with nested fields being flattened and separated by ".". List fields
+// are normalized into a "documents_<field>" table, containing the "document_id",
+// "position" and "value" of each element.
+//
+// Column and table names are case insensitive, so those of fields differing only
+// by case are suffixed by a number, for example "meta_Title_2".
+//
+// Existing tables, including those of list fields no longer present, are
+// replaced.
+func SQLite(filename, path string, opts ...Option) error {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	r, err := get.Get(path, get.Expr(o.expr), get.Glob(o.glob))
+	if err != nil {
+		return err
+	}
+
+	var cols []*column
+
+	seen := make(map[string]bool)
+	names, tables := make(map[string]bool), make(map[string]bool)
+
+	add := func(name string, sels []cue.Selector, v cue.Value, kind cue.Kind) {
+		if seen[name] {
+			return
+		}
+
+		seen[name] = true
+
+		c := &column{path: cue.MakePath(sels...), typ: sqlType(kind)}
+
+		if kind != cue.ListKind {
+			c.name = unique("meta_"+name, names)
+		} else {
+			c.name = unique("documents_"+name, tables)
+			c.list, c.typ = true, sqlType(v.LookupPath(cue.MakePath(cue.AnyIndex)).IncompleteKind())
+
+			if v.IsConcrete() {
+				if i, err := v.List(); err == nil && i.Next() {
+					c.typ = sqlType(i.Value().Kind())
+				}
+			}
+		}
+
+		cols = append(cols, c)
+	}
+
+	if o.schema != nil {
+		if err := o.schema.Err(); err != nil {
+			return fmt.Errorf("invalid schema: %w", err)
+		}
+
+		fields.Walk(o.schema.LookupPath(cue.ParsePath("#Metadata")), func(f fields.Field) {
+			if !f.Struct() {
+				add(f.String(), f.Path, f.Value, f.Value.IncompleteKind())
+			}
+		}, cue.Optional(true))
+	}
+
+	for _, f := range r {
+		fields.Walk(f.Metadata, func(x fields.Field) {
+			if !x.Struct() {
+				add(x.String(), x.Path, x.Value, x.Value.Kind())
+			}
+		})
+	}
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := create(tx, cols); err != nil {
+		return err
+	}
+
+	for _, f := range r {
+		args := []any{f.Name, f.Start, f.End}
+
+		for _, c := range cols {
+			if !c.list {
+				args = append(args, value(f.Metadata.LookupPath(c.path)))
+			}
+		}
+
+		res, err := tx.Exec(insert(cols), args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cols {
+			if !c.list {
+				continue
+			}
+
+			i, err := f.Metadata.LookupPath(c.path).List()
+			if err != nil {
+				continue
+			}
+
+			for n := 0; i.Next(); n++ {
+				q := fmt.Sprintf("INSERT INTO %s (document_id, position, value) VALUES (?, ?, ?)", quote(c.name))
+
+				if _, err := tx.Exec(q, id, n, value(i.Value())); err != nil {
+					return fmt.Errorf("%s: %w", f.Name, err)
+				}
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func create(tx *sql.Tx, cols []*column) error {
+	drops, err := existing(tx)
+	if err != nil {
+		return err
+	}
+
+	var creates []string
+
+	defs := []string{
+		"id INTEGER PRIMARY KEY",
+		"name TEXT NOT NULL UNIQUE",
+		"start INTEGER",
+		`"end" INTEGER`,
+	}
+
+	for _, c := range cols {
+		if !c.list {
+			defs = append(defs, quote(c.name)+" "+c.typ)
+			continue
+		}
+
+		t := quote(c.name)
+
+		creates = append(creates, fmt.Sprintf("CREATE TABLE %s (document_id INTEGER NOT NULL REFERENCES documents(id), position INTEGER NOT NULL, value %s)", t, c.typ))
+	}
+
+	// List tables reference the documents table, so are dropped before, and
+	// created after it.
+	stmts := append(drops, "DROP TABLE IF EXISTS documents", fmt.Sprintf("CREATE TABLE documents (%s)", strings.Join(defs, ", ")))
+
+	for _, s := range append(stmts, creates...) {
+		if _, err := tx.Exec(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// existing returns statements to drop the existing list tables, including those
+// of fields which are no longer present.
+func existing(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name LIKE 'documents\_%' ESCAPE '\'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drops []string
+
+	for rows.Next() {
+		var name string
+
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		drops = append(drops, "DROP TABLE IF EXISTS "+quote(name))
+	}
+
+	return drops, rows.Err()
+}
+
+// unique returns name, suffixed by a number if necessary, such that it differs,
+// case insensitively, from those already taken.
+func unique(name string, taken map[string]bool) string {
+	n := name
+
+	for i := 2; taken[strings.ToLower(n)]; i++ {
+		n = fmt.Sprintf("%s_%d", name, i)
+	}
+
+	taken[strings.ToLower(n)] = true
+
+	return n
+}
+
+func insert(cols []*column) string {
+	names := []string{"name", "start", `"end"`}
+
+	for _, c := range cols {
+		if !c.list {
+			names = append(names, quote(c.name))
+		}
+	}
+
+	return fmt.Sprintf("INSERT INTO documents (%s) VALUES (%s)", strings.Join(names, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", "))
+}
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func sqlType(k cue.Kind) string {
+	switch k {
+	case cue.BoolKind, cue.IntKind:
+		return "INTEGER"
+	case cue.FloatKind, cue.NumberKind:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// value returns v as a value suitable for SQLite, with non-scalar values
+// encoded as JSON.
+func value(v cue.Value) any {
+	if !v.Exists() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case cue.BoolKind:
+		b, _ := v.Bool()
+		return b
+	case cue.IntKind:
+		i, _ := v.Int64()
+		return i
+	case cue.FloatKind, cue.NumberKind:
+		f, _ := v.Float64()
+		return f
+	case cue.StringKind:
+		s, _ := v.String()
+		return s
+	case cue.NullKind:
+		return nil
+	default:
+		b, _ := v.MarshalJSON()
+		return string(b)
+	}
+}
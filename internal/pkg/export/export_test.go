@@ -0,0 +1,122 @@
+package export
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSQLite(t *testing.T) {
+	dir, db := t.TempDir(), filepath.Join(t.TempDir(), "docs.db")
+
+	write(t, filepath.Join(dir, "a.md"), "---\nname: alpha\ntitle: A\nTitle: B\ntags: [x, y]\nowner: {team: docs}\n---\n")
+
+	if err := SQLite(db, dir); err != nil {
+		t.Fatalf("SQLite() = %v", err)
+	}
+
+	if got, want := columns(t, db, "documents"), []string{"id", "name", "start", "end", "meta_name", "meta_title", "meta_Title_2", "meta_owner.team"}; !slices.Equal(got, want) {
+		t.Errorf("columns = %q, want %q", got, want)
+	}
+
+	if got, want := tables(t, db), []string{"documents", "documents_tags"}; !slices.Equal(got, want) {
+		t.Errorf("tables = %q, want %q", got, want)
+	}
+
+	var name, meta, title string
+
+	if err := query(t, db).QueryRow(`SELECT name, meta_name, meta_Title_2 FROM documents`).Scan(&name, &meta, &title); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join(dir, "a.md"); name != want || meta != "alpha" || title != "B" {
+		t.Errorf("row = %q, %q, %q, want %q, %q, %q", name, meta, title, want, "alpha", "B")
+	}
+
+	// Tables of list fields no longer present are dropped.
+	write(t, filepath.Join(dir, "a.md"), "---\ntitle: A\nlabels: [z]\n---\n")
+
+	if err := SQLite(db, dir); err != nil {
+		t.Fatalf("SQLite() = %v", err)
+	}
+
+	if got, want := tables(t, db), []string{"documents", "documents_labels"}; !slices.Equal(got, want) {
+		t.Errorf("tables = %q, want %q", got, want)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := make(map[string]bool)
+
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"meta_title", "meta_title"},
+		{"meta_Title", "meta_Title_2"},
+		{"meta_TITLE", "meta_TITLE_3"},
+		{"meta_title_2", "meta_title_2_2"},
+	} {
+		if got := unique(tc.in, taken); got != tc.want {
+			t.Errorf("unique(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func write(t *testing.T, name, s string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(s), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func query(t *testing.T, filename string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func columns(t *testing.T, filename, table string) []string {
+	t.Helper()
+
+	return strs(t, query(t, filename), `SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+}
+
+func tables(t *testing.T, filename string) []string {
+	t.Helper()
+
+	return strs(t, query(t, filename), `SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name`)
+}
+
+func strs(t *testing.T, db *sql.DB, q string, args ...any) []string {
+	t.Helper()
+
+	rows, err := db.Query(q, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var s []string
+
+	for rows.Next() {
+		var x string
+
+		if err := rows.Scan(&x); err != nil {
+			t.Fatal(err)
+		}
+
+		s = append(s, x)
+	}
+
+	return s
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"cuelang.org/go/cue"
+)
+
+// Option is an option to SQLite.
+type Option interface {
+	apply(*options)
+}
+
+// Expr specifies an expression used to filter files.
+func Expr(e string) Option {
+	return option(func(o *options) {
+		o.expr = e
+	})
+}
+
+// Glob specifies a pattern to filter files.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+// Schema specifies a schema whose #Metadata fields determine the columns, and
+// their types, of exported tables.
+//
+// Fields not present in the schema, or if no schema is specified, have their
+// columns inferred from the metadata of files.
+func Schema(v cue.Value) Option {
+	return option(func(o *options) {
+		o.schema = &v
+	})
+}
+
+type options struct {
+	expr   string
+	glob   string
+	schema *cue.Value
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
@@ -0,0 +1,105 @@
+// Package fields provides functionality to traverse, and interpret, the fields
+// of metadata.
+package fields
+
+import (
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+)
+
+// Field represents an individual field, see Walk.
+type Field struct {
+	Path     []cue.Selector // Path of the field, relative to the walked value.
+	Value    cue.Value      // Value of the field.
+	Optional bool           // Whether the field is optional.
+}
+
+// String returns the path of the field, with nested fields separated by ".".
+func (f Field) String() string {
+	s := make([]string, len(f.Path))
+
+	for i, x := range f.Path {
+		s[i] = x.Unquoted()
+	}
+
+	return strings.Join(s, ".")
+}
+
+// Struct reports whether the field is a struct.
+func (f Field) Struct() bool {
+	return f.Value.IncompleteKind() == cue.StructKind
+}
+
+// Walk calls fn for each field of v, including structs, recursing into the
+// fields of structs after calling fn for the struct itself.
+//
+// Paths consist of string selectors, regardless of whether fields are optional,
+// such that they can be used to look up the fields of other values.
+func Walk(v cue.Value, fn func(Field), opts ...cue.Option) {
+	walk(v, nil, fn, opts...)
+}
+
+func walk(v cue.Value, prefix []cue.Selector, fn func(Field), opts ...cue.Option) {
+	i, err := v.Fields(opts...)
+	if err != nil {
+		return
+	}
+
+	for i.Next() {
+		f := Field{
+			Path:     append(prefix[:len(prefix):len(prefix)], cue.Str(i.Selector().Unquoted())),
+			Value:    i.Value(),
+			Optional: i.Selector().ConstraintType() == cue.OptionalConstraint,
+		}
+
+		fn(f)
+
+		if f.Struct() {
+			walk(f.Value, f.Path, fn, opts...)
+		}
+	}
+}
+
+// Elems returns the elements of v, if it is a list, otherwise v itself.
+func Elems(v cue.Value) []cue.Value {
+	if v.Kind() != cue.ListKind {
+		return []cue.Value{v}
+	}
+
+	var e []cue.Value
+
+	for i, _ := v.List(); i.Next(); {
+		e = append(e, i.Value())
+	}
+
+	return e
+}
+
+// Format returns v as a string, if it is one, otherwise as JSON. An empty string
+// is returned if v cannot be represented as JSON, for example if incomplete.
+func Format(v cue.Value) string {
+	if s, err := v.String(); err == nil {
+		return s
+	}
+
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// Date parses s as either a date (2006-01-02), or a timestamp (RFC 3339),
+// reporting whether it is either.
+func Date(s string) (time.Time, bool) {
+	for _, l := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
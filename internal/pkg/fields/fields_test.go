@@ -0,0 +1,109 @@
+package fields
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestWalk(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+a:  1
+b?: string
+c: {
+	d:     [1, 2]
+	"e.f": true
+}
+`)
+
+	var got []string
+
+	Walk(v, func(f Field) {
+		s := f.String()
+
+		if f.Optional {
+			s += "?"
+		}
+
+		if f.Struct() {
+			s += "{}"
+		}
+
+		got = append(got, s)
+	})
+
+	if want := []string{"a", "c{}", "c.d", "c.e.f"}; !slices.Equal(got, want) {
+		t.Errorf("Walk() = %q, want %q", got, want)
+	}
+
+	got = nil
+
+	Walk(v, func(f Field) {
+		if f.Optional {
+			got = append(got, f.String())
+			return
+		}
+
+		// Paths consist of string selectors, so can be used to look up fields
+		// of data.
+		if x := v.LookupPath(cue.MakePath(f.Path...)); !x.Exists() {
+			t.Errorf("LookupPath(%s) does not exist", f)
+		}
+	}, cue.Optional(true))
+
+	if want := []string{"b"}; !slices.Equal(got, want) {
+		t.Errorf("Walk(cue.Optional(true)) = %q, want %q", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	ctx := cuecontext.New()
+
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{`"a"`, "a"},
+		{`1`, "1"},
+		{`true`, "true"},
+		{`{a: 1}`, `{"a":1}`},
+		{`string`, ""},
+	} {
+		if got := Format(ctx.CompileString(tc.in)); got != tc.want {
+			t.Errorf("Format(%s) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestElems(t *testing.T) {
+	ctx := cuecontext.New()
+
+	if got := Elems(ctx.CompileString(`[1, 2, 3]`)); len(got) != 3 {
+		t.Errorf("Elems(list) = %d elements, want 3", len(got))
+	}
+
+	if got := Elems(ctx.CompileString(`1`)); len(got) != 1 {
+		t.Errorf("Elems(scalar) = %d elements, want 1", len(got))
+	}
+}
+
+func TestDate(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{"2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		{"2024-13-01", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	} {
+		got, ok := Date(tc.in)
+		if ok != tc.ok || !got.Equal(tc.want) {
+			t.Errorf("Date(%q) = %v, %v, want %v, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
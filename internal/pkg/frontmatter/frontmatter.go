@@ -0,0 +1,542 @@
+// Package frontmatter provides functionality to read and modify the (YAML)
+// frontmatter of markdown files.
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document represents a file containing frontmatter.
+type Document struct {
+	Name  string // Name of the file.
+	Start int    // Line number after the opening delimiter.
+	End   int    // Line number before the closing delimiter.
+
+	head []byte // Content up to, and including, the opening delimiter.
+	data []byte // Frontmatter, excluding delimiters.
+	tail []byte // Content from, and including, the closing delimiter.
+}
+
+// Read reads the frontmatter of the given file.
+//
+// A nil document is returned if the file does not contain frontmatter.
+func Read(p string) (*Document, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(p, b)
+}
+
+// Parse parses the frontmatter of the given file contents.
+//
+// Frontmatter must be opened by a delimiter (---) on the first non-blank line,
+// and closed by a subsequent delimiter.
+//
+// A nil document is returned if the contents do not contain frontmatter.
+func Parse(name string, b []byte) (*Document, error) {
+	d := &Document{Name: name}
+
+	var off int
+
+	for i := 1; off < len(b); i++ {
+		l, n := line(b[off:])
+
+		switch {
+		case d.Start == 0 && len(bytes.TrimSpace(l)) == 0:
+			// Skip leading blank lines.
+		case d.Start == 0 && !delim(l):
+			return nil, nil
+		case d.Start == 0:
+			d.Start = i + 1
+			d.head = b[:off+n]
+		case delim(l):
+			d.End = i - 1
+			d.data = b[len(d.head):off]
+			d.tail = b[off:]
+			return d, nil
+		}
+
+		off += n
+	}
+
+	if d.Start == 0 {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%s: frontmatter not closed", name)
+}
+
+// Body returns the content following the closing delimiter.
+func (d *Document) Body() []byte {
+	_, n := line(d.tail)
+	return d.tail[n:]
+}
+
+// Bytes returns the entire contents of the file.
+func (d *Document) Bytes() []byte {
+	b := make([]byte, 0, len(d.head)+len(d.data)+len(d.tail))
+	b = append(b, d.head...)
+	b = append(b, d.data...)
+	return append(b, d.tail...)
+}
+
+// Data returns the frontmatter, excluding delimiters.
+func (d *Document) Data() []byte {
+	return d.data
+}
+
+// Node returns the frontmatter as a YAML mapping node.
+func (d *Document) Node() (*yaml.Node, error) {
+	var n yaml.Node
+
+	if err := yaml.Unmarshal(d.data, &n); err != nil {
+		return nil, fmt.Errorf("%s: %w", d.Name, err)
+	}
+
+	if n.Kind == 0 { // Empty frontmatter.
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+
+	if n.Kind != yaml.DocumentNode || len(n.Content) != 1 || n.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: frontmatter is not a mapping", d.Name)
+	}
+
+	return n.Content[0], nil
+}
+
+// SetNode replaces the frontmatter with the given YAML node.
+//
+// Only the lines of fields which differ from the existing frontmatter are
+// rewritten, with all others, including their comments and formatting, retained
+// byte-for-byte. Rewritten fields retain the comments and styles attached to
+// the node, however their indentation is normalized.
+func (d *Document) SetNode(n *yaml.Node) error {
+	old, err := d.Node()
+	if err != nil || len(old.Content) == 0 || n.Kind != yaml.MappingNode {
+		return d.encode(n)
+	}
+
+	e := &editor{lines: bytes.SplitAfter(d.data, []byte("\n"))}
+
+	if err := e.mapping(old, n, len(e.lines)); err != nil {
+		return fmt.Errorf("%s: %w", d.Name, err)
+	}
+
+	d.data = e.apply()
+	d.End = d.Start + bytes.Count(d.data, []byte("\n")) - 1
+
+	return nil
+}
+
+// encode replaces the frontmatter with the given YAML node, in its entirety.
+func (d *Document) encode(n *yaml.Node) error {
+	var b []byte
+
+	if len(n.Content) > 0 || n.HeadComment != "" || n.FootComment != "" {
+		w := new(bytes.Buffer)
+
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+
+		if err := e.Encode(n); err != nil {
+			return fmt.Errorf("%s: %w", d.Name, err)
+		}
+
+		if err := e.Close(); err != nil {
+			return fmt.Errorf("%s: %w", d.Name, err)
+		}
+
+		b = w.Bytes()
+	}
+
+	d.data = b
+	d.End = d.Start + bytes.Count(b, []byte("\n")) - 1
+
+	return nil
+}
+
+// editor records edits, by line, to existing frontmatter.
+type editor struct {
+	lines [][]byte // Lines of the frontmatter, including line terminators.
+	edits []edit
+}
+
+// edit represents the replacement of lines [start, end) with text.
+type edit struct {
+	start, end int
+	text       []byte
+}
+
+// mapping records the edits required to update the entries of the mapping old,
+// which ends before the given line, to those of n.
+func (e *editor) mapping(old, n *yaml.Node, end int) error {
+	indent := old.Content[0].Column - 1
+	last := old.Content[0].Line - 1 // Line after the last entry.
+
+	for i := 0; i+1 < len(old.Content); i += 2 {
+		k, v := old.Content[i], old.Content[i+1]
+
+		start, stop := k.Line-1, end
+		if i+2 < len(old.Content) {
+			stop = old.Content[i+2].Line - 1
+		}
+
+		stop = e.trim(start, stop)
+		last = stop
+
+		x := lookup(n, k.Value)
+
+		switch {
+		case x == nil:
+			e.edits = append(e.edits, edit{e.comments(start), stop, nil})
+		case equal(v, x) && v.LineComment == x.LineComment:
+		case block(v) && block(x):
+			if err := e.mapping(v, x, stop); err != nil {
+				return err
+			}
+		default:
+			b, err := entry(k, x, indent)
+			if err != nil {
+				return err
+			}
+
+			e.edits = append(e.edits, edit{start, stop, b})
+		}
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if lookup(old, n.Content[i].Value) != nil {
+			continue
+		}
+
+		b, err := entry(n.Content[i], n.Content[i+1], indent)
+		if err != nil {
+			return err
+		}
+
+		e.edits = append(e.edits, edit{last, last, b})
+	}
+
+	return nil
+}
+
+// trim returns the line following the last line of an entry spanning lines
+// [start, end), excluding trailing blank lines and comments, which belong to
+// whichever entry follows.
+func (e *editor) trim(start, end int) int {
+	for end-1 > start && ignorable(e.lines[end-1]) {
+		end--
+	}
+
+	return end
+}
+
+// comments returns the first line of the comments directly preceding the given
+// line.
+func (e *editor) comments(l int) int {
+	for l > 0 && bytes.HasPrefix(bytes.TrimSpace(e.lines[l-1]), []byte("#")) {
+		l--
+	}
+
+	return l
+}
+
+// apply returns the frontmatter with all edits applied.
+func (e *editor) apply() []byte {
+	var b []byte
+
+	for i := 0; i <= len(e.lines); i++ {
+		for _, x := range e.edits {
+			if x.start == i {
+				// Inserted text must follow a complete line.
+				if n := len(b); n > 0 && b[n-1] != '\n' {
+					b = append(b, '\n')
+				}
+
+				b = append(b, x.text...)
+			}
+		}
+
+		if i == len(e.lines) || slices.ContainsFunc(e.edits, func(x edit) bool { return i >= x.start && i < x.end }) {
+			continue
+		}
+
+		b = append(b, e.lines[i]...)
+	}
+
+	return b
+}
+
+// entry encodes the mapping entry of key k and value v, indented by the given
+// number of spaces.
+func entry(k, v *yaml.Node, indent int) ([]byte, error) {
+	// Comments surrounding the entry are retained in place, rather than being
+	// encoded.
+	k, v = ptr(*k), ptr(*v)
+	k.HeadComment, k.FootComment, v.HeadComment, v.FootComment = "", "", "", ""
+
+	w := new(bytes.Buffer)
+
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+
+	if err := e.Encode(&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{k, v}}); err != nil {
+		return nil, err
+	}
+
+	if err := e.Close(); err != nil {
+		return nil, err
+	}
+
+	var b []byte
+
+	for _, l := range bytes.SplitAfter(w.Bytes(), []byte("\n")) {
+		if len(l) > 0 {
+			b = append(append(b, bytes.Repeat([]byte(" "), indent)...), l...)
+		}
+	}
+
+	return b, nil
+}
+
+// block reports whether n is a non-empty, block style, mapping.
+func block(n *yaml.Node) bool {
+	return n.Kind == yaml.MappingNode && n.Style&yaml.FlowStyle == 0 && len(n.Content) > 0
+}
+
+// ignorable reports whether a line is blank, or only a comment.
+func ignorable(l []byte) bool {
+	l = bytes.TrimSpace(l)
+	return len(l) == 0 || l[0] == '#'
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Set sets the field at the given path to the given value, creating any
+// intermediate mappings as necessary.
+func Set(n *yaml.Node, path []string, v *yaml.Node) error {
+	if len(path) == 0 {
+		return errors.New("empty path")
+	}
+
+	for i, k := range path {
+		if n.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: not a mapping", strings.Join(path[:i], "."))
+		}
+
+		x := lookup(n, k)
+
+		if i == len(path)-1 {
+			if x == nil {
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, v)
+				return nil
+			}
+
+			// Retain any comments attached to the existing value.
+			v.HeadComment, v.LineComment, v.FootComment = x.HeadComment, x.LineComment, x.FootComment
+			*x = *v
+
+			return nil
+		}
+
+		if x == nil {
+			x = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, x)
+		}
+
+		n = x
+	}
+
+	return nil
+}
+
+// Unset removes the field at the given path, reporting whether it existed.
+//
+// Mappings left empty by the removal are also removed.
+func Unset(n *yaml.Node, path []string) bool {
+	if len(path) == 0 || n.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != path[0] {
+			continue
+		}
+
+		if v := n.Content[i+1]; len(path) > 1 {
+			if !Unset(v, path[1:]) {
+				return false
+			}
+
+			if len(v.Content) > 0 {
+				return true
+			}
+		}
+
+		n.Content = append(n.Content[:i], n.Content[i+2:]...)
+
+		return true
+	}
+
+	return false
+}
+
+// Merge updates dst to be equivalent to src.
+//
+// Fields and values of dst which are equivalent to those of src are retained
+// as is, preserving their order, style and comments, with timestamps (such as
+// 2023-01-01) being equivalent to the same strings. Fields not present in src
+// are removed, and those only present in src are appended, with timestamps
+// being unquoted.
+func Merge(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		if !equal(dst, src) {
+			unquote(src)
+			src.HeadComment, src.LineComment, src.FootComment = dst.HeadComment, dst.LineComment, dst.FootComment
+			*dst = *src
+		}
+
+		return
+	}
+
+	var content []*yaml.Node
+
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		if v := lookup(src, dst.Content[i].Value); v != nil {
+			Merge(dst.Content[i+1], v)
+			content = append(content, dst.Content[i], dst.Content[i+1])
+		}
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if lookup(dst, src.Content[i].Value) == nil {
+			unquote(src.Content[i+1])
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+
+	dst.Content = content
+}
+
+// unquote removes the quotes of strings within n which are timestamps, such as
+// "2023-01-01", as metadata interprets timestamps as strings, and they are
+// typically written without quotes.
+func unquote(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode {
+		for _, x := range n.Content {
+			unquote(x)
+		}
+
+		return
+	}
+
+	if x := (&yaml.Node{Kind: yaml.ScalarNode, Value: n.Value}); n.ShortTag() == "!!str" && x.ShortTag() == "!!timestamp" {
+		n.Tag, n.Style = "", 0
+	}
+}
+
+func equal(a, b *yaml.Node) bool {
+	x, err := decode(a)
+	if err != nil {
+		return false
+	}
+
+	y, err := decode(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// decode decodes n as metadata is interpreted, that is, with timestamps, such as
+// 2023-01-01, being strings rather than times.
+func decode(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+
+		return decode(n.Content[0])
+	case yaml.AliasNode:
+		return decode(n.Alias)
+	case yaml.SequenceNode:
+		l := make([]any, len(n.Content))
+
+		for i, x := range n.Content {
+			v, err := decode(x)
+			if err != nil {
+				return nil, err
+			}
+
+			l[i] = v
+		}
+
+		return l, nil
+	case yaml.MappingNode:
+		m := make(map[string]any)
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := decode(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			m[n.Content[i].Value] = v
+		}
+
+		return m, nil
+	}
+
+	if n.ShortTag() == "!!timestamp" {
+		return n.Value, nil
+	}
+
+	var v any
+
+	err := n.Decode(&v)
+
+	return v, err
+}
+
+func lookup(n *yaml.Node, k string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == k {
+			return n.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func delim(l []byte) bool {
+	return string(bytes.TrimSuffix(l, []byte("\r"))) == "---"
+}
+
+// line returns the first line of b, excluding the line terminator, along with
+// the number of bytes consumed, including the line terminator.
+func line(b []byte) ([]byte, int) {
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return b, len(b)
+	}
+
+	return b[:i], i + 1
+}
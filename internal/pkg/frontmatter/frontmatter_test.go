@@ -0,0 +1,377 @@
+package frontmatter
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const doc = `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`
+
+func TestSet(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		path  []string
+		value string
+		want  string
+	}{
+		{
+			name:  "scalar",
+			path:  []string{"status"},
+			value: "published",
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: published
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name:  "retains comment",
+			path:  []string{"title"},
+			value: "Beta",
+			want: `---
+# Document metadata.
+title: Beta # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name:  "nested",
+			path:  []string{"meta", "team"},
+			value: "platform",
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team: platform
+---
+Body.
+`,
+		},
+		{
+			name:  "list",
+			path:  []string{"tags"},
+			value: "[api]",
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags: [api]
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name:  "new",
+			path:  []string{"reviewed"},
+			value: "2024-01-01",
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+reviewed: 2024-01-01
+---
+Body.
+`,
+		},
+		{
+			name:  "new nested",
+			path:  []string{"meta", "reviewer"},
+			value: "bob",
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+  reviewer: bob
+---
+Body.
+`,
+		},
+		{
+			name:  "unchanged",
+			path:  []string{"title"},
+			value: "Alpha",
+			want:  doc,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, n := parse(t, doc)
+
+			var v yaml.Node
+
+			if err := yaml.Unmarshal([]byte(tc.value), &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Set(n, tc.path, v.Content[0]); err != nil {
+				t.Fatalf("Set() = %v", err)
+			}
+
+			check(t, d, n, tc.want)
+		})
+	}
+}
+
+func TestUnset(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		path []string
+		ok   bool
+		want string
+	}{
+		{
+			name: "scalar",
+			path: []string{"status"},
+			ok:   true,
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name: "with comment",
+			path: []string{"title"},
+			ok:   true,
+			want: `---
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  owner: alice   # owner
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name: "nested",
+			path: []string{"meta", "owner"},
+			ok:   true,
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+
+tags:
+    - api
+    - cli
+meta:
+  team:  docs
+---
+Body.
+`,
+		},
+		{
+			name: "missing",
+			path: []string{"meta", "reviewer"},
+			want: doc,
+		},
+		{
+			name: "missing parent",
+			path: []string{"status", "owner"},
+			want: doc,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, n := parse(t, doc)
+
+			if ok := Unset(n, tc.path); ok != tc.ok {
+				t.Errorf("Unset() = %v, want %v", ok, tc.ok)
+			}
+
+			check(t, d, n, tc.want)
+		})
+	}
+}
+
+func TestUnsetEmpty(t *testing.T) {
+	d, n := parse(t, "---\ntitle: Alpha\nmeta:\n  review:\n    by: alice\nstatus: draft\n---\nBody.\n")
+
+	if ok := Unset(n, []string{"meta", "review", "by"}); !ok {
+		t.Errorf("Unset() = %v, want %v", ok, true)
+	}
+
+	check(t, d, n, "---\ntitle: Alpha\nstatus: draft\n---\nBody.\n")
+}
+
+func TestMerge(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "equivalent",
+			src:  `{title: Alpha, status: draft, tags: [api, cli], meta: {owner: alice, team: docs}}`,
+			want: doc,
+		},
+		{
+			name: "changed",
+			src:  `{title: Alpha, status: published, tags: [api, cli], meta: {owner: bob, team: docs}, summary: Hi}`,
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: published
+
+tags:
+    - api
+    - cli
+meta:
+  owner: bob # owner
+  team:  docs
+summary: Hi
+---
+Body.
+`,
+		},
+		{
+			name: "removed",
+			src:  `{title: Alpha, meta: {owner: alice}}`,
+			want: `---
+# Document metadata.
+title:   "Alpha"   # trailing
+
+meta:
+  owner: alice   # owner
+---
+Body.
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, n := parse(t, doc)
+
+			var src yaml.Node
+
+			if err := yaml.Unmarshal([]byte(tc.src), &src); err != nil {
+				t.Fatal(err)
+			}
+
+			Merge(n, src.Content[0])
+
+			check(t, d, n, tc.want)
+		})
+	}
+}
+
+func TestMergeTimestamps(t *testing.T) {
+	d, n := parse(t, "---\nreviewed: 2023-01-01\ndates: [2023-01-01, 2023-02-01]\n---\nBody.\n")
+
+	var src yaml.Node
+
+	if err := yaml.Unmarshal([]byte(`{reviewed: "2023-01-01", dates: ["2023-01-01", "2023-02-01"]}`), &src); err != nil {
+		t.Fatal(err)
+	}
+
+	Merge(n, src.Content[0])
+
+	check(t, d, n, "---\nreviewed: 2023-01-01\ndates: [2023-01-01, 2023-02-01]\n---\nBody.\n")
+}
+
+func TestSetNodeEmpty(t *testing.T) {
+	d, n := parse(t, "---\n---\nBody.\n")
+
+	if err := Set(n, []string{"title"}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "Alpha"}); err != nil {
+		t.Fatal(err)
+	}
+
+	check(t, d, n, "---\ntitle: Alpha\n---\nBody.\n")
+}
+
+func parse(t *testing.T, s string) (*Document, *yaml.Node) {
+	t.Helper()
+
+	d, err := Parse("test.md", []byte(s))
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+
+	n, err := d.Node()
+	if err != nil {
+		t.Fatalf("Node() = %v", err)
+	}
+
+	return d, n
+}
+
+func check(t *testing.T, d *Document, n *yaml.Node, want string) {
+	t.Helper()
+
+	if err := d.SetNode(n); err != nil {
+		t.Fatalf("SetNode() = %v", err)
+	}
+
+	if got := string(d.Bytes()); got != want {
+		t.Errorf("SetNode() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := d.Node(); err != nil {
+		t.Errorf("Node() = %v", err)
+	}
+}
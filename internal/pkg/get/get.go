@@ -2,108 +2,210 @@
 package get
 
 import (
-	"bufio"
-	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path/filepath"
+	"slices"
 
+	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/parser"
 	"cuelang.org/go/encoding/yaml"
+	"github.com/bmatcuk/doublestar/v4"
 
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	_frontmatter "github.com/slewiskelly/ock/internal/pkg/frontmatter"
 	"github.com/slewiskelly/ock/internal/pkg/report"
 )
 
 // Get retrieves metadata from (markdown) files rooted at the given path.
 func Get(path string, opts ...Option) (report.Report, error) {
+	var r report.Report
+
+	err := Walk(path, func(f *report.File) error {
+		r = append(r, f)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Walk retrieves metadata from (markdown) files rooted at the given path,
+// calling fn for each file as soon as its metadata is retrieved.
+//
+// If files are to be sorted, the metadata of all files is retrieved before fn
+// is first called.
+func Walk(path string, fn func(*report.File) error, opts ...Option) error {
 	o := &options{}
 
 	for _, opt := range opts {
 		opt.apply(o)
 	}
 
-	var r report.Report
+	if ok := doublestar.ValidatePathPattern(o.glob); !ok {
+		return errors.New("invalid globbing pattern")
+	}
 
-	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
+	if o.expr != "" {
+		if _, err := parser.ParseExpr("expression", o.expr); err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
 		}
+	}
+
+	if o.sort == "" {
+		var n int
+
+		return walk(path, o, func(f *report.File) error {
+			if n++; n <= o.offset {
+				return nil
+			}
+
+			if err := fn(f); err != nil {
+				return err
+			}
+
+			if o.limit > 0 && n-o.offset >= o.limit {
+				return fs.SkipAll
+			}
 
-		if !d.Type().IsRegular() || filepath.Ext(p) != ".md" {
 			return nil
-		}
+		})
+	}
 
-		f, err := frontmatter(p)
-		if err != nil || f == nil {
-			return err
-		}
+	sort := cue.ParsePath(o.sort)
+	if err := sort.Err(); err != nil {
+		return fmt.Errorf("invalid sort path: %w", err)
+	}
 
-		r = append(r, f)
+	var r report.Report
 
+	err := walk(path, o, func(f *report.File) error {
+		r = append(r, f)
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if o.expr == "" {
-		return r, nil
-	}
+	slices.SortStableFunc(r, func(a, b *report.File) int {
+		x, y := a.Metadata.LookupPath(sort), b.Metadata.LookupPath(sort)
+
+		// Files without the field are always ordered last.
+		switch {
+		case !x.Exists() && !y.Exists():
+			return 0
+		case !x.Exists():
+			return 1
+		case !y.Exists():
+			return -1
+		}
 
-	// TODO(slewiskelly): Support filtering via expression.
+		if o.desc {
+			return compare(y, x)
+		}
 
-	return r, nil
-}
+		return compare(x, y)
+	})
 
-func frontmatter(p string) (*report.File, error) {
-	f, err := os.Open(p)
-	if err != nil {
-		return nil, err
+	if o.offset > 0 {
+		r = r[min(o.offset, len(r)):]
 	}
-	defer f.Close()
 
-	s := bufio.NewScanner(f)
+	if o.limit > 0 && len(r) > o.limit {
+		r = r[:o.limit]
+	}
 
-	var opened, closed int
+	for _, f := range r {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
 
-	for i := 1; s.Scan(); i++ {
-		l := s.Bytes()
+	return nil
+}
 
-		if len(bytes.TrimSpace(l)) == 0 {
-			continue
+// walk calls fn for each (markdown) file rooted at the given path, which has
+// metadata, and satisfies the glob and expression.
+func walk(path string, o *options, fn func(*report.File) error) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
 		}
 
-		if string(l) != "---" {
-			return nil, nil
+		if o.glob != "" && !doublestar.PathMatchUnvalidated(o.glob, p) {
+			return nil
 		}
 
-		opened = i
-		break
-	}
+		if !d.Type().IsRegular() || filepath.Ext(p) != ".md" {
+			return nil
+		}
 
-	if opened == 0 {
-		return nil, nil
-	}
+		f, err := frontmatter(p)
+		if err != nil || f == nil {
+			return err
+		}
 
-	var b []byte
+		if o.expr != "" {
+			if ok, err := Match(f.Metadata, o.expr); err != nil || !ok {
+				return err
+			}
+		}
 
-	for i := opened + 1; s.Scan(); i++ {
-		l := s.Bytes()
+		return fn(f)
+	})
+}
 
-		if string(l) == "---" {
-			closed = i
-			break
+// compare compares two values, numerically if both are numbers, chronologically
+// if both are dates, and lexically otherwise.
+func compare(a, b cue.Value) int {
+	ka, kb := a.Kind(), b.Kind()
+
+	switch {
+	case ka&cue.NumberKind != 0 && kb&cue.NumberKind != 0:
+		x, _ := a.Float64()
+		y, _ := b.Float64()
+
+		return cmp.Compare(x, y)
+	case ka == cue.StringKind && kb == cue.StringKind:
+		x, _ := a.String()
+		y, _ := b.String()
+
+		if s, ok := fields.Date(x); ok {
+			if t, ok := fields.Date(y); ok {
+				return s.Compare(t)
+			}
 		}
 
-		b = append(b, append(l, '\n')...)
+		return cmp.Compare(x, y)
+	case ka == cue.BoolKind && kb == cue.BoolKind:
+		x, _ := a.Bool()
+		y, _ := b.Bool()
+
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		default:
+			return 1
+		}
+	default:
+		return cmp.Compare(ka, kb)
 	}
+}
 
-	if closed <= opened {
-		return nil, fmt.Errorf("%s: frontmatter not closed", p)
+func frontmatter(p string) (*report.File, error) {
+	d, err := _frontmatter.Read(p)
+	if err != nil || d == nil {
+		return nil, err
 	}
 
-	y, err := yaml.Extract(p, b)
+	y, err := yaml.Extract(p, d.Data())
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +218,26 @@ func frontmatter(p string) (*report.File, error) {
 	return &report.File{
 		Name:     p,
 		Metadata: v,
-		Start:    opened + 1,
-		End:      closed - 1,
+		Start:    d.Start,
+		End:      d.End,
 	}, nil
 }
+
+// Match reports whether the given metadata satisfies the given expression.
+//
+// The expression is evaluated within the scope of the metadata, meaning fields
+// can be referenced directly, and must evaluate to a boolean. Expressions which
+// cannot be evaluated, for example due to referencing a field which does not
+// exist, are considered not to match.
+func Match(v cue.Value, expr string) (bool, error) {
+	if _, err := parser.ParseExpr("expression", expr); err != nil {
+		return false, fmt.Errorf("invalid expression: %w", err)
+	}
+
+	b, err := v.Context().CompileString(expr, cue.Scope(v), cue.InferBuiltins(true)).Bool()
+	if err != nil {
+		return false, nil
+	}
+
+	return b, nil
+}
@@ -0,0 +1,87 @@
+package get
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestCompare(t *testing.T) {
+	ctx := cuecontext.New()
+
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{`1`, `2`, -1},
+		{`10`, `9`, 1},
+		{`1.5`, `1`, 1},
+		{`2`, `2.0`, 0},
+		{`"a"`, `"b"`, -1},
+		{`"b"`, `"a"`, 1},
+		{`"2024-01-02"`, `"2024-01-10"`, -1},
+		{`"2024-01-02T12:00:00Z"`, `"2024-01-02"`, 1},
+		{`false`, `true`, -1},
+		{`true`, `true`, 0},
+		{`"10"`, `"9"`, -1}, // Strings are compared lexically.
+	} {
+		if got := compare(ctx.CompileString(tc.a), ctx.CompileString(tc.b)); got != tc.want {
+			t.Errorf("compare(%s, %s) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"a.md":      "---\ntitle: A\npriority: 2\nreviewed: 2024-03-01\n---\n",
+		"b.md":      "---\ntitle: B\npriority: 10\n---\n",
+		"c.md":      "---\ntitle: C\npriority: 1\nreviewed: 2023-12-31\n---\n",
+		"d.md":      "---\ntitle: D\n---\n",
+		"e.md":      "No frontmatter.\n",
+		"notes.txt": "---\ntitle: Ignored\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"unsorted", nil, []string{"a.md", "b.md", "c.md", "d.md"}},
+		{"numeric", []Option{Sort("priority", false)}, []string{"c.md", "a.md", "b.md", "d.md"}},
+		{"descending", []Option{Sort("priority", true)}, []string{"b.md", "a.md", "c.md", "d.md"}},
+		{"dates", []Option{Sort("reviewed", false)}, []string{"c.md", "a.md", "b.md", "d.md"}},
+		{"offset", []Option{Sort("priority", false), Offset(1)}, []string{"a.md", "b.md", "d.md"}},
+		{"limit", []Option{Sort("priority", false), Limit(2)}, []string{"c.md", "a.md"}},
+		{"page", []Option{Sort("priority", false), Offset(1), Limit(2)}, []string{"a.md", "b.md"}},
+		{"past end", []Option{Offset(10)}, nil},
+		{"unsorted page", []Option{Offset(1), Limit(2)}, []string{"b.md", "c.md"}},
+		{"expression", []Option{Expr("priority > 1"), Sort("title", true)}, []string{"b.md", "a.md"}},
+		{"glob", []Option{Glob("**/[ab].md")}, []string{"a.md", "b.md"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Get(dir, tc.opts...)
+			if err != nil {
+				t.Fatalf("Get() = %v", err)
+			}
+
+			var got []string
+
+			for _, f := range r {
+				got = append(got, filepath.Base(f.Name))
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Get() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
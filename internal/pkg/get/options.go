@@ -5,15 +5,52 @@ type Option interface {
 	apply(*options)
 }
 
-// Expr specifies an expression used to filter fields.
+// Expr specifies an expression used to filter files.
 func Expr(e string) Option {
 	return option(func(o *options) {
 		o.expr = e
 	})
 }
 
+// Glob specifies a pattern to filter files.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+// Limit specifies the maximum number of files to retrieve.
+func Limit(n int) Option {
+	return option(func(o *options) {
+		o.limit = n
+	})
+}
+
+// Offset specifies the number of files to skip, after filtering and sorting.
+func Offset(n int) Option {
+	return option(func(o *options) {
+		o.offset = n
+	})
+}
+
+// Sort specifies the path of a field by which files are ordered, ascending or
+// descending.
+//
+// Numbers are compared numerically, dates (RFC3339) chronologically, and other
+// strings lexically. Files without the field are ordered last.
+func Sort(path string, desc bool) Option {
+	return option(func(o *options) {
+		o.sort, o.desc = path, desc
+	})
+}
+
 type options struct {
-	expr string
+	desc   bool
+	expr   string
+	glob   string
+	limit  int
+	offset int
+	sort   string
 }
 
 type option func(*options)
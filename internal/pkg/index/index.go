@@ -0,0 +1,251 @@
+// Package index provides functionality to render an index of documents.
+package index
+
+import (
+	"cmp"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/slewiskelly/ock/internal/pkg/get"
+)
+
+// Data is the data with which templates are executed.
+type Data struct {
+	Documents []*Document // Documents to be indexed.
+	Fields    []string    // Union of the (top-level) fields of all documents.
+}
+
+// Document represents an individual document.
+type Document struct {
+	Name     string         // Name of the file.
+	Link     string         // Location of the file, relative to the index.
+	Metadata map[string]any // File's metadata.
+}
+
+// Field returns the value of the field at the given path, with nested fields
+// separated by ".", or nil if it does not exist.
+func (d *Document) Field(path string) any {
+	var v any = d.Metadata
+
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		v = m[k]
+	}
+
+	return v
+}
+
+// Title returns the document's title, falling back to its name.
+func (d *Document) Title() string {
+	if s, ok := d.Field("title").(string); ok && s != "" {
+		return s
+	}
+
+	return d.Name
+}
+
+// Group represents the documents sharing an individual value of a field.
+type Group struct {
+	Key       string      // Value of the field, empty for documents without the field.
+	Documents []*Document // Documents with the value.
+}
+
+// Index renders an index of (markdown) files rooted at the given path, to the
+// given writer.
+//
+// The template is either the name of a built-in template (owners, table, or
+// tags), or the location of a template file. Template files with an ".html"
+// extension, optionally followed by ".tmpl", are rendered as HTML.
+//
+// Templates are executed with Data, and may additionally use the following
+// functions:
+//
+//	format  formats a value, joining list elements with ", "
+//	groupBy groups documents by each distinct value of a field
+//	inline  formats a value on a single line, such that it may be used within
+//	        a table cell, or heading, escaping "|" in markdown
+//	repeat  repeats a string a number of times
+func Index(w io.Writer, path, tmpl string, opts ...Option) error {
+	o := &options{
+		base: ".",
+	}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	name, src, html, err := load(tmpl, o.html)
+	if err != nil {
+		return err
+	}
+
+	gopts := []get.Option{get.Expr(o.expr), get.Glob(o.glob)}
+
+	if o.sort != "" {
+		gopts = append(gopts, get.Sort(o.sort, o.desc))
+	}
+
+	r, err := get.Get(path, gopts...)
+	if err != nil {
+		return err
+	}
+
+	d := &Data{}
+	fields := make(map[string]bool)
+
+	for _, f := range r {
+		x := &Document{Name: f.Name, Link: f.Name}
+
+		if err := f.Metadata.Decode(&x.Metadata); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		if l, err := filepath.Rel(o.base, f.Name); err == nil {
+			x.Link = filepath.ToSlash(l)
+		}
+
+		for k := range x.Metadata {
+			if !fields[k] {
+				fields[k] = true
+				d.Fields = append(d.Fields, k)
+			}
+		}
+
+		d.Documents = append(d.Documents, x)
+	}
+
+	slices.Sort(d.Fields)
+
+	if html {
+		t, err := htmltemplate.New(name).Funcs(funcs).Funcs(htmltemplate.FuncMap{"inline": inlineHTML}).Parse(src)
+		if err != nil {
+			return err
+		}
+
+		return t.Execute(w, d)
+	}
+
+	t, err := texttemplate.New(name).Funcs(funcs).Parse(src)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(w, d)
+}
+
+// Templates returns the names of the built-in templates.
+func Templates() []string {
+	return []string{"owners", "table", "tags"}
+}
+
+var funcs = map[string]any{
+	"format":  format,
+	"groupBy": groupBy,
+	"inline":  inline,
+	"repeat":  strings.Repeat,
+}
+
+func load(tmpl string, html bool) (name, src string, _ bool, _ error) {
+	if slices.Contains(Templates(), tmpl) {
+		name = tmpl + ".md.tmpl"
+		if html {
+			name = tmpl + ".html.tmpl"
+		}
+
+		b, err := templates.ReadFile("templates/" + name)
+
+		return name, string(b), html, err
+	}
+
+	b, err := os.ReadFile(tmpl)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	name = filepath.Base(tmpl)
+
+	return name, string(b), filepath.Ext(strings.TrimSuffix(name, ".tmpl")) == ".html", nil
+}
+
+func format(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case []any:
+		s := make([]string, len(x))
+		for i, e := range x {
+			s[i] = format(e)
+		}
+		return strings.Join(s, ", ")
+	case map[string]any:
+		b, _ := json.Marshal(x)
+		return string(b)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// lineBreaks replaces line breaks with <br>, which is permitted within table
+// cells of (GitHub flavored) markdown, as well as HTML.
+var lineBreaks = strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func inline(v any) string {
+	return lineBreaks.Replace(strings.ReplaceAll(format(v), "|", `\|`))
+}
+
+func inlineHTML(v any) htmltemplate.HTML {
+	return htmltemplate.HTML(lineBreaks.Replace(htmltemplate.HTMLEscapeString(format(v))))
+}
+
+func groupBy(path string, docs []*Document) []Group {
+	groups := make(map[string][]*Document)
+
+	for _, d := range docs {
+		v := d.Field(path)
+
+		if l, ok := v.([]any); ok {
+			for _, e := range l {
+				groups[format(e)] = append(groups[format(e)], d)
+			}
+			continue
+		}
+
+		groups[format(v)] = append(groups[format(v)], d)
+	}
+
+	var g []Group
+
+	for k, docs := range groups {
+		g = append(g, Group{Key: k, Documents: docs})
+	}
+
+	slices.SortFunc(g, func(a, b Group) int {
+		switch {
+		case a.Key == "":
+			return 1
+		case b.Key == "":
+			return -1
+		default:
+			return cmp.Compare(a.Key, b.Key)
+		}
+	})
+
+	return g
+}
+
+//go:embed templates
+var templates embed.FS
@@ -0,0 +1,154 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"a.md": "---\ntitle: Alpha\nowner: alice\ntags: [api, cli]\n---\n",
+		"b.md": "---\ntitle: B | c\nowner: bob\nsummary: \"line\\nbreak\"\n---\n",
+		"c.md": "---\ntitle: C\nstatus: draft\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		tmpl string
+		html bool
+		want string
+	}{
+		{
+			tmpl: "table",
+			want: `| Document | owner | status | summary | tags |
+|----------|-------|--------|---------|------|
+| [Alpha](a.md) | alice |  |  | api, cli |
+| [B \| c](b.md) | bob |  | line<br>break |  |
+| [C](c.md) |  | draft |  |  |
+`,
+		},
+		{
+			tmpl: "owners",
+			want: `## alice
+
+- [Alpha](a.md)
+
+## bob
+
+- [B \| c](b.md)
+
+## Unowned
+
+- [C](c.md)
+`,
+		},
+		{
+			tmpl: "tags",
+			want: `## api
+
+- [Alpha](a.md)
+
+## cli
+
+- [Alpha](a.md)
+
+## Untagged
+
+- [B \| c](b.md)
+- [C](c.md)
+`,
+		},
+		{
+			tmpl: "table",
+			html: true,
+			want: `<table>
+  <thead>
+    <tr>
+      <th>Document</th>
+      <th>owner</th>
+      <th>status</th>
+      <th>summary</th>
+      <th>tags</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td><a href="a.md">Alpha</a></td>
+      <td>alice</td>
+      <td></td>
+      <td></td>
+      <td>api, cli</td>
+    </tr>
+    <tr>
+      <td><a href="b.md">B | c</a></td>
+      <td>bob</td>
+      <td></td>
+      <td>line<br>break</td>
+      <td></td>
+    </tr>
+    <tr>
+      <td><a href="c.md">C</a></td>
+      <td></td>
+      <td>draft</td>
+      <td></td>
+      <td></td>
+    </tr>
+  </tbody>
+</table>
+`,
+		},
+	} {
+		t.Run(tc.tmpl, func(t *testing.T) {
+			w := new(strings.Builder)
+
+			if err := Index(w, dir, tc.tmpl, Base(dir), HTML(tc.html)); err != nil {
+				t.Fatalf("Index() = %v", err)
+			}
+
+			if got := w.String(); got != tc.want {
+				t.Errorf("Index() =\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIndexFile(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("---\ntitle: \"<b>Alpha</b>\"\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"index.md.tmpl", `{{range .Documents}}{{inline .Title}}{{end}}`, "<b>Alpha</b>"},
+		{"index.html.tmpl", `{{range .Documents}}{{inline .Title}}{{end}}`, "&lt;b&gt;Alpha&lt;/b&gt;"},
+		{"index.html", `{{range .Documents}}{{.Title}}{{end}}`, "&lt;b&gt;Alpha&lt;/b&gt;"},
+	} {
+		tmpl := filepath.Join(t.TempDir(), tc.name)
+
+		if err := os.WriteFile(tmpl, []byte(tc.src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		w := new(strings.Builder)
+
+		if err := Index(w, dir, tmpl); err != nil {
+			t.Fatalf("Index(%s) = %v", tc.name, err)
+		}
+
+		if got := w.String(); got != tc.want {
+			t.Errorf("Index(%s) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
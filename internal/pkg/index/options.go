@@ -0,0 +1,60 @@
+package index
+
+// Option is an option to Index.
+type Option interface {
+	apply(*options)
+}
+
+// Base specifies the directory which links to files are relative to, typically
+// the directory the index is written to.
+//
+// By default, links are relative to the current working directory.
+func Base(dir string) Option {
+	return option(func(o *options) {
+		o.base = dir
+	})
+}
+
+// Expr specifies an expression used to filter files.
+func Expr(e string) Option {
+	return option(func(o *options) {
+		o.expr = e
+	})
+}
+
+// Glob specifies a pattern to filter files.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+// HTML specifies that the HTML variant of a built-in template should be used.
+func HTML(b bool) Option {
+	return option(func(o *options) {
+		o.html = b
+	})
+}
+
+// Sort specifies the path of a field by which files are ordered, ascending or
+// descending.
+func Sort(path string, desc bool) Option {
+	return option(func(o *options) {
+		o.sort, o.desc = path, desc
+	})
+}
+
+type options struct {
+	base string
+	desc bool
+	expr string
+	glob string
+	html bool
+	sort string
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
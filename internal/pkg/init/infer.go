@@ -0,0 +1,224 @@
+package init
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/literal"
+
+	"github.com/slewiskelly/ock/internal/pkg/get"
+)
+
+// maxEnum is the maximum number of distinct values a string field may have to
+// be inferred as an enumeration.
+const maxEnum = 10
+
+// infer infers a schema from the metadata of (markdown) files rooted at the
+// given path.
+func infer(path string) ([]byte, error) {
+	r, err := get.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r) == 0 {
+		return nil, fmt.Errorf("no metadata found under %s", path)
+	}
+
+	root := &field{}
+
+	for _, f := range r {
+		root.observe(f.Metadata)
+	}
+
+	g := &generator{}
+
+	body := g.expr(root)
+
+	w := new(strings.Builder)
+
+	fmt.Fprintf(w, "// Inferred from the metadata of %d file(s) under %s.\n\n", len(r), path)
+
+	if g.time {
+		fmt.Fprintf(w, "import \"time\"\n\n")
+	}
+
+	fmt.Fprintf(w, "#Metadata: %s\n", body)
+
+	return format.Source([]byte(w.String()), format.Simplify())
+}
+
+// field represents the observed values of an individual field.
+type field struct {
+	count int      // Number of occurrences.
+	kinds cue.Kind // Kinds of all occurrences.
+
+	// Strings.
+	strings int            // Number of string occurrences.
+	values  map[string]int // Occurrences of each string value.
+	dates   bool           // Whether all strings are dates.
+	times   bool           // Whether all strings are timestamps.
+
+	// Lists.
+	elem *field
+
+	// Structs.
+	structs int               // Number of struct occurrences.
+	fields  map[string]*field // Fields of structs.
+	order   []string          // Names of fields, in order of first occurrence.
+}
+
+func (f *field) observe(v cue.Value) {
+	f.count++
+	f.kinds |= v.Kind()
+
+	switch v.Kind() {
+	case cue.StringKind:
+		s, _ := v.String()
+
+		if f.values == nil {
+			f.values = make(map[string]int)
+			f.dates, f.times = true, true
+		}
+
+		f.strings++
+		f.values[s]++
+
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			f.dates = false
+		}
+
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			f.times = false
+		}
+	case cue.ListKind:
+		if f.elem == nil {
+			f.elem = &field{}
+		}
+
+		for i, _ := v.List(); i.Next(); {
+			f.elem.observe(i.Value())
+		}
+	case cue.StructKind:
+		if f.fields == nil {
+			f.fields = make(map[string]*field)
+		}
+
+		f.structs++
+
+		for i, _ := v.Fields(); i.Next(); {
+			name := i.Selector().Unquoted()
+
+			if _, ok := f.fields[name]; !ok {
+				f.fields[name] = &field{}
+				f.order = append(f.order, name)
+			}
+
+			f.fields[name].observe(i.Value())
+		}
+	}
+}
+
+type generator struct {
+	time bool // Whether the time package is referenced.
+}
+
+// expr returns the CUE expression constraining the observed values of f.
+func (g *generator) expr(f *field) string {
+	var exprs []string
+
+	for _, k := range []cue.Kind{cue.NullKind, cue.BoolKind, cue.IntKind, cue.FloatKind, cue.StringKind, cue.ListKind, cue.StructKind} {
+		if f.kinds&k == 0 {
+			continue
+		}
+
+		switch k {
+		case cue.NullKind:
+			exprs = append(exprs, "null")
+		case cue.BoolKind:
+			exprs = append(exprs, "bool")
+		case cue.IntKind:
+			if f.kinds&cue.FloatKind == 0 {
+				exprs = append(exprs, "int")
+			}
+		case cue.FloatKind:
+			exprs = append(exprs, "number")
+		case cue.StringKind:
+			exprs = append(exprs, g.string(f))
+		case cue.ListKind:
+			if f.elem == nil || f.elem.count == 0 {
+				exprs = append(exprs, "[...]")
+			} else {
+				exprs = append(exprs, fmt.Sprintf("[...%s]", g.expr(f.elem)))
+			}
+		case cue.StructKind:
+			exprs = append(exprs, g.structure(f))
+		}
+	}
+
+	if len(exprs) == 0 {
+		return "_"
+	}
+
+	return strings.Join(exprs, " | ")
+}
+
+func (g *generator) string(f *field) string {
+	switch {
+	case f.dates:
+		g.time = true
+		return "time.Format(time.RFC3339Date)"
+	case f.times:
+		g.time = true
+		return "time.Time()"
+	case len(f.values) <= maxEnum && len(f.values)*2 <= f.strings:
+		// Low cardinality, with values recurring.
+		var values []string
+
+		for v := range f.values {
+			values = append(values, v)
+		}
+
+		slices.Sort(values)
+
+		for i, v := range values {
+			values[i] = literal.String.Quote(v)
+		}
+
+		return strings.Join(values, " | ")
+	default:
+		return "string"
+	}
+}
+
+func (g *generator) structure(f *field) string {
+	w := new(strings.Builder)
+
+	fmt.Fprintln(w, "{")
+
+	for _, name := range f.order {
+		x := f.fields[name]
+
+		label := name
+		if !ast.IsValidIdent(name) || strings.HasPrefix(name, "#") || strings.HasPrefix(name, "_") {
+			label = literal.Label.Quote(name)
+		}
+
+		// Fields which are not present in every struct are optional.
+		if x.count < f.structs {
+			fmt.Fprintf(w, "// Present in %d of %d.\n", x.count, f.structs)
+			label += "?"
+		}
+
+		fmt.Fprintf(w, "%s: %s\n", label, g.expr(x))
+	}
+
+	fmt.Fprint(w, "}")
+
+	return w.String()
+}
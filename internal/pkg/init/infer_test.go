@@ -0,0 +1,69 @@
+package init
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"a.md": "---\ntitle: A\nstatus: draft\nreviewed: 2024-01-01\nweight: 1\ntags: [api]\nmeta: {owner: alice}\n---\n",
+		"b.md": "---\ntitle: B\nstatus: published\nreviewed: 2024-02-01\nweight: 1.5\ntags: []\nmeta: {owner: bob, since: 2024-01-01T00:00:00Z}\n---\n",
+		"c.md": "---\ntitle: C\nstatus: draft\nreviewed: 2024-03-01\nweight: two\n\"my-key\": true\nmeta: {owner: carol}\n---\n",
+		"d.md": "---\ntitle: D\nstatus: published\nreviewed: 2024-04-01\nweight: null\nmeta: {owner: dan}\n---\n",
+		"e.md": "No frontmatter.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Fields present in every file are required, strings whose values recur
+	// (status) are enumerations, unlike those which are distinct (title, owner),
+	// and fields of mixed types (weight) are disjunctions.
+	want := `// Inferred from the metadata of 4 file(s) under DIR.
+
+import "time"
+
+#Metadata: {
+	title:    string
+	status:   "draft" | "published"
+	reviewed: time.Format(time.RFC3339Date)
+	weight:   null | number | string
+	// Present in 2 of 4.
+	tags?: [...string]
+	meta: {
+		owner: string
+		// Present in 1 of 4.
+		since?: time.Time()
+	}
+	// Present in 1 of 4.
+	"my-key"?: bool
+}
+`
+
+	b, err := infer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.ReplaceAll(string(b), dir, "DIR"); got != want {
+		t.Errorf("infer() = %s, want %s", got, want)
+	}
+}
+
+func TestInferEmpty(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("No frontmatter.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := infer(dir); err == nil {
+		t.Errorf("infer() = nil, want error")
+	}
+}
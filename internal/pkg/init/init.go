@@ -2,14 +2,34 @@
 package init
 
 import (
+	"bufio"
+	"bytes"
 	"embed"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
+
+	"github.com/slewiskelly/ock/internal/pkg/schema"
 )
 
-// Init initializes a default schema with the given filename.
+// Template represents a schema template.
+type Template struct {
+	Name        string // Name of the template.
+	Description string // Description of the template, from its leading comment.
+}
+
+// Init initializes a schema with the given filename, from the template of the
+// given name, or the default template if empty.
+//
+// Templates are either built-in, or read from a directory (see Dir). Otherwise,
+// if no template exists with the given name, it is considered to be the
+// location of a local file.
+//
+// Alternatively, the schema may be imported from an existing JSON Schema, or
+// OpenAPI, definition, or inferred from the metadata of existing files, but not
+// both.
 //
 // By default, if a file with the given name already exists, it will not attempt
 // to overwrite it, and an error will occur.
@@ -20,6 +40,10 @@ func Init(filename, def string, opts ...Option) error {
 		opt.apply(o)
 	}
 
+	if o.from != "" && o.infer != "" {
+		return errors.New("a schema can either be imported or inferred, not both")
+	}
+
 	_, err := os.Stat(filename)
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -31,7 +55,17 @@ func Init(filename, def string, opts ...Option) error {
 		return fmt.Errorf("file %s already exists, specify --force to overwrite", filename)
 	}
 
-	b, err := f.ReadFile("schema.cue")
+	var b []byte
+
+	switch {
+	case o.from != "":
+		b, err = schema.Import(o.from)
+	case o.infer != "":
+		b, err = infer(o.infer)
+	default:
+		b, err = template(def, o.dir)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -39,5 +73,79 @@ func Init(filename, def string, opts ...Option) error {
 	return os.WriteFile(filename, b, 0o644)
 }
 
-//go:embed schema.cue
+// Templates returns the templates within the given directory, or the built-in
+// templates if empty.
+func Templates(dir string) ([]Template, error) {
+	fsys := templates(dir)
+
+	m, err := fs.Glob(fsys, "*.cue")
+	if err != nil {
+		return nil, err
+	}
+
+	var t []Template
+
+	for _, name := range m {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		t = append(t, Template{Name: strings.TrimSuffix(name, ".cue"), Description: description(b)})
+	}
+
+	return t, nil
+}
+
+func template(def, dir string) ([]byte, error) {
+	if def == "" {
+		def = "default"
+	}
+
+	// Relative (./), or absolute, locations, and files, are never names of
+	// built-in templates.
+	if dir == "" && (!fs.ValidPath(def) || strings.HasSuffix(def, ".cue")) {
+		return os.ReadFile(def)
+	}
+
+	b, err := fs.ReadFile(templates(dir), def+".cue")
+	if err != nil && dir == "" {
+		// Not a built-in template, but possibly a local file.
+		b, err = os.ReadFile(def)
+	}
+
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+		return nil, fmt.Errorf("unknown template %q", def)
+	}
+
+	return b, err
+}
+
+func templates(dir string) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+
+	fsys, _ := fs.Sub(f, "templates")
+
+	return fsys
+}
+
+// description returns the leading comment of a template.
+func description(b []byte) string {
+	var d []string
+
+	for s := bufio.NewScanner(bytes.NewReader(b)); s.Scan(); {
+		l, ok := strings.CutPrefix(strings.TrimSpace(s.Text()), "//")
+		if !ok {
+			break
+		}
+
+		d = append(d, strings.TrimSpace(l))
+	}
+
+	return strings.Join(d, " ")
+}
+
+//go:embed templates/*.cue
 var f embed.FS
@@ -0,0 +1,64 @@
+package init
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitFromAndInfer(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(filepath.Join(dir, ".schema.cue"), "", From("openapi.yaml"), Infer(dir)); err == nil {
+		t.Errorf("Init(From(), Infer()) = nil, want error")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	local := filepath.Join(dir, "local.cue")
+
+	if err := os.WriteFile(local, []byte("#Metadata: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	for _, tc := range []struct {
+		def  string
+		dir  string
+		want string // Prefix of the template.
+		err  bool
+	}{
+		{def: "", want: "import"},
+		{def: "runbook", want: "//"},
+		{def: local, want: "#Metadata"},
+		{def: "./local.cue", want: "#Metadata"},
+		{def: "local.cue", want: "#Metadata"},
+		{def: "local", dir: dir, want: "#Metadata"},
+		{def: "nope", err: true},
+		{def: "./nope.cue", err: true},
+		{def: "../nope", dir: dir, err: true},
+	} {
+		b, err := template(tc.def, tc.dir)
+		if (err != nil) != tc.err {
+			t.Errorf("template(%q, %q) = %v, want error: %v", tc.def, tc.dir, err, tc.err)
+			continue
+		}
+
+		if !strings.HasPrefix(string(b), tc.want) {
+			t.Errorf("template(%q, %q) = %q, want prefix %q", tc.def, tc.dir, b, tc.want)
+		}
+	}
+}
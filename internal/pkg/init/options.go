@@ -5,6 +5,14 @@ type Option interface {
 	apply(*options)
 }
 
+// Dir specifies a directory from which templates are read, rather than the
+// built-in templates.
+func Dir(dir string) Option {
+	return option(func(o *options) {
+		o.dir = dir
+	})
+}
+
 // Force specifies that an existing schema file should be overwritten.
 func Force(b bool) Option {
 	return option(func(o *options) {
@@ -12,8 +20,31 @@ func Force(b bool) Option {
 	})
 }
 
+// From specifies a JSON Schema, or OpenAPI, file from which the schema should
+// be imported, rather than initializing the default schema.
+func From(filename string) Option {
+	return option(func(o *options) {
+		o.from = filename
+	})
+}
+
+// Infer specifies a path, under which the metadata of (markdown) files should be
+// used to infer the schema, rather than initializing the default schema.
+//
+// Fields present in every file are required, and otherwise optional. String
+// fields with few, recurring, values are inferred as enumerations, and those
+// containing dates, or timestamps, as such.
+func Infer(path string) Option {
+	return option(func(o *options) {
+		o.infer = path
+	})
+}
+
 type options struct {
+	dir   string
 	force bool
+	from  string
+	infer string
 }
 
 type option func(*options)
@@ -0,0 +1,23 @@
+// Architecture decision records.
+
+import "time"
+
+#Metadata: {
+	title:  string
+	status: #Status
+	date:   #Date
+	deciders: [...#Owner]
+	supersedes?:   string
+	supersededBy?: string
+	tags?: [...string]
+
+	if status == "superseded" {
+		supersededBy: string
+	}
+}
+
+#Status: "proposed" | "accepted" | "rejected" | "deprecated" | "superseded"
+
+#Date: time.Format(time.RFC3339Date)
+
+#Owner: string
@@ -0,0 +1,29 @@
+// Docusaurus docs front matter.
+
+#Metadata: {
+	id?:                 string
+	title:               string
+	description?:        string
+	slug?:               string
+	sidebar_label?:      string
+	sidebar_position?:   number
+	sidebar_class_name?: string
+	keywords?: [...string]
+	tags?: [...string]
+	draft?:                  bool
+	unlisted?:               bool
+	hide_title?:             bool
+	hide_table_of_contents?: bool
+	toc_min_heading_level?:  >=2 & <=6
+	toc_max_heading_level?:  >=2 & <=6
+	pagination_label?:       string
+	pagination_next?:        string | null
+	pagination_prev?:        string | null
+	custom_edit_url?:        string | null
+	image?:                  string
+	last_update?: {
+		date?:   string
+		author?: string
+	}
+	...
+}
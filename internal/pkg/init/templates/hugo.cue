@@ -0,0 +1,27 @@
+// Hugo content front matter.
+
+import "time"
+
+#Metadata: {
+	title:        string
+	date:         #Date
+	lastmod?:     #Date
+	publishDate?: #Date
+	expiryDate?:  #Date
+	draft:        *false | bool
+	description?: string
+	summary?:     string
+	slug?:        string
+	url?:         string
+	type?:        string
+	layout?:      string
+	weight?:      int
+	aliases?: [...string]
+	tags?: [...string]
+	categories?: [...string]
+	keywords?: [...string]
+	params?: {...}
+	...
+}
+
+#Date: time.Format(time.RFC3339Date) | time.Time()
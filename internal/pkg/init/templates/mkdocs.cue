@@ -0,0 +1,16 @@
+// MkDocs (Material) page front matter.
+
+#Metadata: {
+	title?:       string
+	description?: string
+	template?:    string
+	icon?:        string
+	status?:      string
+	tags?: [...string]
+	hide?: [...("navigation" | "toc" | "footer" | "tags" | "feedback" | "path")]
+	search?: {
+		exclude?: bool
+		boost?:   number
+	}
+	...
+}
@@ -0,0 +1,22 @@
+// Operational runbooks.
+
+import "time"
+
+#Metadata: {
+	title:    string
+	service:  string
+	owner:    #Owner
+	severity: #Severity
+	reviewed: #Date
+	tested?:  #Date
+	alerts?: [...string]
+	dashboards?: [...string]
+	escalation?: [...#Owner]
+	tags: [...string]
+}
+
+#Severity: "sev1" | "sev2" | "sev3" | "sev4"
+
+#Date: time.Format(time.RFC3339Date)
+
+#Owner: string
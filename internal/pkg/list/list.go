@@ -2,14 +2,22 @@
 package list
 
 import (
+	"cmp"
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"slices"
 
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
 	"github.com/slewiskelly/ock/internal/pkg/get"
+	"github.com/slewiskelly/ock/internal/pkg/report"
 )
 
 // List lists (markdown) files rooted at the given path.
+//
+// If sorted, files without the field, or without metadata, are ordered last.
 func List(path string, opts ...Option) ([]string, error) {
 	o := &options{}
 
@@ -17,6 +25,21 @@ func List(path string, opts ...Option) ([]string, error) {
 		opt.apply(o)
 	}
 
+	if o.sort != "" {
+		r, err := documents(path, o)
+		if err != nil {
+			return nil, err
+		}
+
+		var files []string
+
+		for _, f := range r {
+			files = append(files, f.Name)
+		}
+
+		return paginate(files, o), nil
+	}
+
 	var files []string
 
 	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
@@ -44,6 +67,142 @@ func List(path string, opts ...Option) ([]string, error) {
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(files, o), nil
+}
+
+// documents returns the metadata of files rooted at the given path, sorted if
+// specified, followed by files without metadata, unless filtered by an
+// expression, which such files can never satisfy.
+func documents(path string, o *options) (report.Report, error) {
+	gopts := []get.Option{get.Expr(o.expr)}
+
+	if o.sort != "" {
+		gopts = append(gopts, get.Sort(o.sort, o.desc))
+	}
+
+	r, err := get.Get(path, gopts...)
+	if err != nil || o.expr != "" {
+		return r, err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, f := range r {
+		seen[f.Name] = true
+	}
+
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() && filepath.Ext(p) == ".md" && !seen[p] {
+			r = append(r, &report.File{Name: p})
+		}
+
+		return nil
+	})
+
+	return r, err
+}
+
+// paginate returns the files within the offset and limit.
+func paginate[T any](files []T, o *options) []T {
+	if o.offset > 0 {
+		files = files[min(o.offset, len(files)):]
+	}
+
+	if o.limit > 0 && len(files) > o.limit {
+		files = files[:o.limit]
+	}
+
+	return files
+}
+
+// Group represents the files sharing an individual value of a field.
+type Group struct {
+	Key   string   `json:"key"`   // Value of the field, empty for files without the field.
+	Files []string `json:"files"` // Files with the value.
+}
+
+// GroupBy lists (markdown) files rooted at the given path, grouped by each
+// distinct value of the field at the given path.
+//
+// Files whose field is a list are included in the group of each element. Files
+// without the field, whose field is an empty list, or without metadata, are
+// grouped under an empty key, which is ordered last.
+func GroupBy(path, by string, opts ...Option) ([]Group, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	p := cue.ParsePath(by)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("invalid group path: %w", err)
+	}
+
+	r, err := documents(path, o)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]string)
+
+	for _, f := range paginate(r, o) {
+		for _, k := range values(f.Metadata, p) {
+			groups[k] = append(groups[k], f.Name)
+		}
+	}
+
+	var g []Group
+
+	for k, files := range groups {
+		g = append(g, Group{Key: k, Files: files})
+	}
+
+	slices.SortFunc(g, func(a, b Group) int {
+		switch {
+		case a.Key == "":
+			return 1
+		case b.Key == "":
+			return -1
+		default:
+			return cmp.Compare(a.Key, b.Key)
+		}
+	})
+
+	return g, nil
+}
+
+// values returns the (formatted) value of the field at the given path, or of
+// each of its elements, if it is a list. An empty key is returned for missing
+// fields, and empty lists.
+func values(v cue.Value, p cue.Path) []string {
+	if !v.Exists() {
+		return []string{""}
+	}
+
+	if v = v.LookupPath(p); !v.Exists() {
+		return []string{""}
+	}
+
+	var s []string
+
+	for _, x := range fields.Elems(v) {
+		if str := fields.Format(x); str != "" || x.Kind() == cue.StringKind {
+			s = append(s, str)
+		}
+	}
+
+	if len(s) == 0 {
+		return []string{""}
+	}
 
-	return files, err
+	return s
 }
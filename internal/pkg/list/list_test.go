@@ -0,0 +1,126 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func setup(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"a.md": "---\nstatus: draft\npriority: 2\ntags: [api, cli]\n---\n",
+		"b.md": "---\nstatus: published\npriority: 1\ntags: []\n---\n",
+		"c.md": "---\nstatus: draft\ntags: [api]\n---\n",
+		"d.md": "No frontmatter.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestList(t *testing.T) {
+	dir := setup(t)
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"all", nil, []string{"a.md", "b.md", "c.md", "d.md"}},
+		{"sorted", []Option{Sort("priority", false)}, []string{"b.md", "a.md", "c.md", "d.md"}},
+		{"descending", []Option{Sort("priority", true)}, []string{"a.md", "b.md", "c.md", "d.md"}},
+		{"sorted page", []Option{Sort("priority", false), Offset(2), Limit(5)}, []string{"c.md", "d.md"}},
+		{"page", []Option{Offset(1), Limit(2)}, []string{"b.md", "c.md"}},
+		{"expression", []Option{Expr(`status == "draft"`)}, []string{"a.md", "c.md"}},
+		{"sorted expression", []Option{Expr(`status == "draft"`), Sort("priority", false)}, []string{"a.md", "c.md"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := List(dir, tc.opts...)
+			if err != nil {
+				t.Fatalf("List() = %v", err)
+			}
+
+			if got := base(files); !slices.Equal(got, tc.want) {
+				t.Errorf("List() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	dir := setup(t)
+
+	for _, tc := range []struct {
+		name string
+		by   string
+		opts []Option
+		want map[string][]string
+		keys []string
+	}{
+		{
+			name: "scalar",
+			by:   "status",
+			want: map[string][]string{"draft": {"a.md", "c.md"}, "published": {"b.md"}, "": {"d.md"}},
+			keys: []string{"draft", "published", ""},
+		},
+		{
+			name: "list",
+			by:   "tags",
+			want: map[string][]string{"api": {"a.md", "c.md"}, "cli": {"a.md"}, "": {"b.md", "d.md"}},
+			keys: []string{"api", "cli", ""},
+		},
+		{
+			name: "missing",
+			by:   "priority",
+			opts: []Option{Sort("priority", true)},
+			want: map[string][]string{"2": {"a.md"}, "1": {"b.md"}, "": {"c.md", "d.md"}},
+			keys: []string{"1", "2", ""},
+		},
+		{
+			name: "expression",
+			by:   "tags",
+			opts: []Option{Expr(`status == "published"`)},
+			want: map[string][]string{"": {"b.md"}},
+			keys: []string{""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := GroupBy(dir, tc.by, tc.opts...)
+			if err != nil {
+				t.Fatalf("GroupBy() = %v", err)
+			}
+
+			var keys []string
+
+			for _, x := range g {
+				keys = append(keys, x.Key)
+
+				if got := base(x.Files); !slices.Equal(got, tc.want[x.Key]) {
+					t.Errorf("GroupBy()[%q] = %q, want %q", x.Key, got, tc.want[x.Key])
+				}
+			}
+
+			if !slices.Equal(keys, tc.keys) {
+				t.Errorf("GroupBy() keys = %q, want %q", keys, tc.keys)
+			}
+		})
+	}
+}
+
+func base(files []string) []string {
+	var b []string
+
+	for _, f := range files {
+		b = append(b, filepath.Base(f))
+	}
+
+	return b
+}
@@ -12,8 +12,39 @@ func Expr(e string) Option {
 	})
 }
 
+// Limit specifies the maximum number of files to list.
+func Limit(n int) Option {
+	return option(func(o *options) {
+		o.limit = n
+	})
+}
+
+// Offset specifies the number of files to skip, after filtering and sorting.
+func Offset(n int) Option {
+	return option(func(o *options) {
+		o.offset = n
+	})
+}
+
+// Sort specifies the path of a metadata field by which files are ordered,
+// ascending or descending.
+//
+// Numbers are compared numerically, dates (RFC3339) chronologically, and other
+// strings lexically. Files without the field are ordered last, followed by
+// files without metadata, which are only listed if not filtered by an
+// expression.
+func Sort(path string, desc bool) Option {
+	return option(func(o *options) {
+		o.sort, o.desc = path, desc
+	})
+}
+
 type options struct {
-	expr string
+	desc   bool
+	expr   string
+	limit  int
+	offset int
+	sort   string
 }
 
 type option func(*options)
@@ -0,0 +1,88 @@
+// Package migrate provides functionality to transform document metadata.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/slewiskelly/ock/internal/pkg/frontmatter"
+	"github.com/slewiskelly/ock/internal/pkg/set"
+)
+
+// Migrate transforms the metadata of (markdown) files rooted at the given path.
+//
+// The transformation is expressed in CUE; each file's metadata is filled into
+// the "in" field of the transformation, with the "out" field becoming its
+// replacement. For example, to rename "lastReviewed" to "reviewed":
+//
+//	in: {...}
+//	out: {
+//		for k, v in in if k != "lastReviewed" {(k): v}
+//		if in.lastReviewed != _|_ {reviewed: in.lastReviewed}
+//	}
+//
+// Fields whose values are unchanged by the transformation retain their
+// original formatting and comments.
+//
+// The returned changes contain only files whose contents were modified, or
+// could not be modified.
+func Migrate(path string, transform cue.Value, opts ...Option) ([]set.Change, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	sopts := []set.Option{set.DryRun(o.dryRun), set.Expr(o.expr), set.Glob(o.glob)}
+
+	if o.schema != nil {
+		sopts = append(sopts, set.Schema(*o.schema))
+	}
+
+	if err := transform.Err(); err != nil {
+		return nil, fmt.Errorf("invalid transformation: %w", err)
+	}
+
+	if !transform.LookupPath(cue.ParsePath("out")).Exists() {
+		return nil, errors.New("invalid transformation: out is not defined")
+	}
+
+	return set.Modify(path, func(n *yamlv3.Node) error {
+		b, err := yamlv3.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		y, err := yaml.Extract("", b)
+		if err != nil {
+			return err
+		}
+
+		out := transform.FillPath(cue.ParsePath("in"), transform.Context().BuildFile(y)).LookupPath(cue.ParsePath("out"))
+		if err := out.Validate(cue.Concrete(true)); err != nil {
+			return fmt.Errorf("failed to transform: %w", err)
+		}
+
+		if b, err = yaml.Encode(out); err != nil {
+			return err
+		}
+
+		var x yamlv3.Node
+
+		if err := yamlv3.Unmarshal(b, &x); err != nil {
+			return err
+		}
+
+		if len(x.Content) != 1 || x.Content[0].Kind != yamlv3.MappingNode {
+			return errors.New("failed to transform: out is not a struct")
+		}
+
+		frontmatter.Merge(n, x.Content[0])
+
+		return nil
+	}, sopts...)
+}
@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+const doc = `---
+# Document metadata.
+title:   "Alpha"   # trailing
+lastReviewed: 2023-01-01
+tags: api
+---
+Body.
+`
+
+func TestMigrate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		transform string
+		opts      []Option
+		want      string // Empty if unchanged.
+		errs      bool
+	}{
+		{
+			name: "rename",
+			transform: `in: {...}
+out: {
+	for k, v in in if k != "lastReviewed" {(k): v}
+	if in.lastReviewed != _|_ {reviewed: in.lastReviewed}
+}`,
+			want: "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\ntags: api\nreviewed: 2023-01-01\n---\nBody.\n",
+		},
+		{
+			name: "convert",
+			transform: `in: {...}
+out: {
+	for k, v in in if k != "tags" {(k): v}
+	if in.tags != _|_ {tags: [...string] & ([in.tags] | in.tags)}
+}`,
+			want: "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nlastReviewed: 2023-01-01\ntags:\n  - api\n---\nBody.\n",
+		},
+		{
+			name:      "identity",
+			transform: `in: {...}, out: in`,
+		},
+		{
+			name:      "dry run",
+			transform: `in: {...}, out: {in, status: "draft"}`,
+			opts:      []Option{DryRun(true)},
+		},
+		{
+			name:      "invalid",
+			transform: `in: {...}, out: {in, status: string}`,
+			errs:      true,
+		},
+		{
+			name:      "schema",
+			transform: `in: {...}, out: {in, status: "draft"}`,
+			opts:      []Option{Schema(cuecontext.New().CompileString(`#Metadata: {status: "published", ...}`))},
+			errs:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := filepath.Join(dir, "a.md")
+
+			if err := os.WriteFile(p, []byte(doc), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := Migrate(dir, cuecontext.New().CompileString(tc.transform), tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, x := range c {
+				if (len(x.Errors) > 0) != tc.errs {
+					t.Errorf("Migrate() errors = %q, want errors: %v", x.Errors, tc.errs)
+				}
+			}
+
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tc.want
+			if want == "" {
+				want = doc
+			}
+
+			if got := string(b); got != want {
+				t.Errorf("Migrate() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	for _, s := range []string{`in: {...}`, `out: in.x`, `in: {...}, out: "x"`} {
+		t.Run(s, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte(doc), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := Migrate(dir, cuecontext.New().CompileString(s))
+			if err == nil && (len(c) != 1 || len(c[0].Errors) == 0) {
+				t.Errorf("Migrate(%q) = %+v, want error", s, c)
+			}
+		})
+	}
+}
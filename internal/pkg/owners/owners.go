@@ -0,0 +1,226 @@
+// Package owners provides functionality to read a source of truth of owners,
+// either a CODEOWNERS file, or a (YAML or CSV) roster.
+package owners
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// Owners represents a source of truth of owners.
+type Owners struct {
+	names map[string]bool
+	root  string // Directory which CODEOWNERS patterns are relative to.
+	rules []rule // CODEOWNERS rules, if read from a CODEOWNERS file.
+}
+
+// rule represents an individual CODEOWNERS rule.
+type rule struct {
+	patterns []string
+	owners   []string
+}
+
+// Read reads owners from the given file.
+//
+// Files with a .yaml (or .yml) extension are read as a list of owners, either
+// as strings, or as mappings with a name field, or as a mapping whose keys are
+// owners. Files with a .csv extension are read as records, with a header, whose
+// owners are taken from the column named "owner" or "name", otherwise the first.
+// Files with any other extension are read as CODEOWNERS files.
+func Read(p string) (*Owners, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *Owners
+
+	switch filepath.Ext(p) {
+	case ".yaml", ".yml":
+		o, err = fromYAML(b)
+	case ".csv":
+		o, err = fromCSV(b)
+	default:
+		o, err = fromCODEOWNERS(b, root(p))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+
+	return o, nil
+}
+
+// Exists reports whether the given owner exists.
+//
+// Owners are compared case insensitively, and without any leading @.
+func (o *Owners) Exists(owner string) bool {
+	return o.names[normalize(owner)]
+}
+
+// For returns the owners of the given file, according to the last matching
+// CODEOWNERS rule, along with whether owners are mapped to files at all, that
+// is, whether they were read from a CODEOWNERS file.
+func (o *Owners) For(p string) ([]string, bool) {
+	if o.rules == nil {
+		return nil, false
+	}
+
+	if abs, err := filepath.Abs(p); err == nil {
+		if rel, err := filepath.Rel(o.root, abs); err == nil {
+			p = rel
+		}
+	}
+
+	p = filepath.ToSlash(p)
+
+	for _, r := range slices.Backward(o.rules) {
+		for _, x := range r.patterns {
+			if doublestar.MatchUnvalidated(x, p) {
+				return r.owners, true
+			}
+		}
+	}
+
+	return nil, true
+}
+
+// Owns reports whether owner is one of the given owners.
+func Owns(owners []string, owner string) bool {
+	return slices.ContainsFunc(owners, func(x string) bool {
+		return normalize(x) == normalize(owner)
+	})
+}
+
+func fromCODEOWNERS(b []byte, dir string) (*Owners, error) {
+	o := &Owners{names: make(map[string]bool), root: dir, rules: []rule{}}
+
+	for s := bufio.NewScanner(bytes.NewReader(b)); s.Scan(); {
+		l, _, _ := strings.Cut(s.Text(), "#")
+
+		f := strings.Fields(l)
+		if len(f) < 1 {
+			continue
+		}
+
+		for _, x := range f[1:] {
+			o.names[normalize(x)] = true
+		}
+
+		o.rules = append(o.rules, rule{patterns: patterns(f[0]), owners: f[1:]})
+	}
+
+	return o, nil
+}
+
+func fromYAML(b []byte) (*Owners, error) {
+	var v any
+
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	o := &Owners{names: make(map[string]bool)}
+
+	switch v := v.(type) {
+	case []any:
+		for _, x := range v {
+			switch x := x.(type) {
+			case string:
+				o.names[normalize(x)] = true
+			case map[string]any:
+				if n, ok := x["name"].(string); ok {
+					o.names[normalize(n)] = true
+				}
+			}
+		}
+	case map[string]any:
+		for k := range v {
+			o.names[normalize(k)] = true
+		}
+	default:
+		return nil, errors.New("expected a list, or mapping, of owners")
+	}
+
+	return o, nil
+}
+
+func fromCSV(b []byte) (*Owners, error) {
+	r, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Owners{names: make(map[string]bool)}
+
+	if len(r) < 1 {
+		return o, nil
+	}
+
+	col := slices.IndexFunc(r[0], func(s string) bool {
+		s = strings.ToLower(strings.TrimSpace(s))
+		return s == "owner" || s == "name"
+	})
+
+	for _, x := range r[1:] {
+		if i := max(col, 0); i < len(x) && strings.TrimSpace(x[i]) != "" {
+			o.names[normalize(x[i])] = true
+		}
+	}
+
+	return o, nil
+}
+
+// patterns returns the (doublestar) patterns equivalent to a CODEOWNERS, that
+// is, gitignore style, pattern.
+//
+// Patterns containing a slash, other than a trailing one, are relative to the
+// root, whereas others match at any depth. Patterns match directories, as well
+// as files, in which case they match all files within, unless their last
+// segment contains a wildcard, such that "docs/*" matches the files of docs, but
+// not those of its subdirectories.
+func patterns(s string) []string {
+	dir := strings.HasSuffix(s, "/")
+	s = strings.TrimSuffix(s, "/")
+
+	if strings.Contains(s, "/") {
+		s = strings.TrimPrefix(s, "/")
+	} else {
+		s = "**/" + s
+	}
+
+	switch {
+	case dir:
+		return []string{s + "/**"}
+	case strings.Contains(s[strings.LastIndex(s, "/")+1:], "*"):
+		return []string{s}
+	default:
+		return []string{s, s + "/**"}
+	}
+}
+
+// root returns the directory which the patterns of the given CODEOWNERS file
+// are relative to, that is, the root of the repository.
+func root(p string) string {
+	dir, _ := filepath.Abs(filepath.Dir(p))
+
+	switch filepath.Base(dir) {
+	case ".github", ".gitlab", "docs":
+		return filepath.Dir(dir)
+	default:
+		return dir
+	}
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+}
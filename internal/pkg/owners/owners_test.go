@@ -0,0 +1,135 @@
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+func TestPatterns(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		want    []string
+	}{
+		{"*", []string{"**/*"}},
+		{"*.md", []string{"**/*.md"}},
+		{"docs", []string{"**/docs", "**/docs/**"}},
+		{"docs/", []string{"**/docs/**"}},
+		{"/docs", []string{"docs", "docs/**"}},
+		{"docs/api", []string{"docs/api", "docs/api/**"}},
+		{"docs/*", []string{"docs/*"}},
+		{"docs/*.md", []string{"docs/*.md"}},
+		{"docs/**", []string{"docs/**"}},
+		{"/docs/**/api", []string{"docs/**/api", "docs/**/api/**"}},
+	} {
+		if got := patterns(tc.pattern); !slices.Equal(got, tc.want) {
+			t.Errorf("patterns(%q) = %q, want %q", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestPatternsMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "docs/a.md", true},
+		{"*.md", "docs/api/a.md", true},
+		{"docs", "docs/api/a.md", true},
+		{"docs", "src/docs/a.md", true},
+		{"/docs", "src/docs/a.md", false},
+		{"docs/", "docs/a.md", true},
+		{"docs/*", "docs/a.md", true},
+		{"docs/*", "docs/api/a.md", false},
+		{"docs/**", "docs/api/a.md", true},
+		{"docs/api", "docs/api/v1/a.md", true},
+		{"docs/api", "src/docs/api/a.md", false},
+	} {
+		got := slices.ContainsFunc(patterns(tc.pattern), func(p string) bool {
+			return doublestar.MatchUnvalidated(p, tc.path)
+		})
+
+		if got != tc.want {
+			t.Errorf("patterns(%q) match %q = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestFor(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.Mkdir(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(dir, ".github", "CODEOWNERS")
+
+	if err := os.WriteFile(p, []byte("# Owners.\n*  @everyone\ndocs/*  @docs\ndocs/api/  @api @Docs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := Read(p)
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@everyone"}},
+		{"docs/a.md", []string{"@docs"}},
+		{"docs/guides/a.md", []string{"@everyone"}},
+		{"docs/api/a.md", []string{"@api", "@Docs"}},
+	} {
+		got, ok := o.For(filepath.Join(dir, tc.path))
+		if !ok || !slices.Equal(got, tc.want) {
+			t.Errorf("For(%q) = %q, %v, want %q, true", tc.path, got, ok, tc.want)
+		}
+	}
+
+	if !o.Exists("DOCS") || o.Exists("nobody") {
+		t.Errorf("Exists() does not compare case insensitively, without any leading @")
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"list.yaml", "- alice\n- name: bob\n", []string{"alice", "bob"}},
+		{"map.yml", "alice: {}\nbob: {}\n", []string{"alice", "bob"}},
+		{"named.csv", "team,owner\ndocs,alice\napi,bob\n", []string{"alice", "bob"}},
+		{"first.csv", "login,team\nalice,docs\nbob,api\n", []string{"alice", "bob"}},
+	} {
+		p := filepath.Join(dir, tc.name)
+
+		if err := os.WriteFile(p, []byte(tc.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		o, err := Read(p)
+		if err != nil {
+			t.Errorf("Read(%q) = %v", tc.name, err)
+			continue
+		}
+
+		for _, n := range tc.want {
+			if !o.Exists(n) {
+				t.Errorf("Read(%q).Exists(%q) = false, want true", tc.name, n)
+			}
+		}
+
+		if _, ok := o.For("a.md"); ok {
+			t.Errorf("Read(%q).For() = _, true, want false", tc.name)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package query
+
+// Option is an option to Query.
+type Option interface {
+	apply(*options)
+}
+
+// Expr specifies an expression used to filter files, prior to querying.
+func Expr(e string) Option {
+	return option(func(o *options) {
+		o.expr = e
+	})
+}
+
+// Glob specifies a pattern to filter files, prior to querying.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+type options struct {
+	expr string
+	glob string
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
@@ -0,0 +1,64 @@
+// Package query provides functionality to query the metadata of a collection
+// of documents.
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
+	"github.com/slewiskelly/ock/internal/pkg/get"
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+// Query evaluates the given program, expressed in CUE, against the metadata of
+// all (markdown) files rooted at the given path.
+//
+// The program is evaluated within a scope containing "files", a list of each
+// file's name, start, end and metadata, allowing projections, joins and
+// aggregations over all files. For example:
+//
+//	[for f in files if f.metadata.status == "draft" {f.name}]
+//
+// Builtin packages may be used without being imported, and references to fields
+// which are not present in a file's metadata must be guarded, as they are
+// otherwise an error.
+func Query(path, program string, opts ...Option) (cue.Value, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	r, err := get.Get(path, get.Expr(o.expr), get.Glob(o.glob))
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	if r == nil {
+		r = report.Report{}
+	}
+
+	// Metadata is retrieved within separate contexts, so is (re)built within
+	// that of the program.
+	b, err := json.Marshal(map[string]any{"files": r})
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	ctx := cuecontext.New()
+
+	scope := ctx.CompileBytes(b)
+	if err := scope.Err(); err != nil {
+		return cue.Value{}, err
+	}
+
+	v := ctx.CompileString(program, cue.Filename("program"), cue.Scope(scope), cue.InferBuiltins(true))
+	if err := v.Validate(cue.Concrete(true)); err != nil {
+		return cue.Value{}, fmt.Errorf("invalid program: %w", err)
+	}
+
+	return v, nil
+}
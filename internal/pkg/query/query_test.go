@@ -0,0 +1,112 @@
+package query
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"a.md": "---\ntitle: A\nstatus: draft\ntags: [api, cli]\n---\n",
+		"b.md": "---\ntitle: B\nstatus: published\ntags: [api]\n---\n",
+		"c.md": "---\ntitle: C\nstatus: draft\n---\n",
+		"d.md": "No frontmatter.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		opts    []Option
+		want    string // JSON, with the directory replaced by DIR.
+	}{
+		{
+			name:    "filter",
+			program: `[for f in files if f.metadata.status == "draft" {f.name}]`,
+			want:    `["DIR/a.md","DIR/c.md"]`,
+		},
+		{
+			name:    "projection",
+			program: `[for f in files {title: f.metadata.title, start: f.start, end: f.end}]`,
+			want:    `[{"title":"A","start":2,"end":4},{"title":"B","start":2,"end":4},{"title":"C","start":2,"end":3}]`,
+		},
+		{
+			name:    "guarded",
+			program: `[for f in files if f.metadata.tags != _|_ for t in f.metadata.tags {t}]`,
+			want:    `["api","cli","api"]`,
+		},
+		{
+			name:    "aggregation",
+			program: `{total: len(files), tagged: len([for f in files if f.metadata.tags != _|_ {f}]), titles: strings.Join([for f in files {f.metadata.title}], ",")}`,
+			want:    `{"total":3,"tagged":2,"titles":"A,B,C"}`,
+		},
+		{
+			name:    "expr",
+			program: `[for f in files {f.metadata.title}]`,
+			opts:    []Option{Expr(`status == "published"`)},
+			want:    `["B"]`,
+		},
+		{
+			name:    "glob",
+			program: `[for f in files {f.metadata.title}]`,
+			opts:    []Option{Glob("**/c.md")},
+			want:    `["C"]`,
+		},
+		{
+			name:    "no files",
+			program: `len(files)`,
+			opts:    []Option{Expr(`status == "archived"`)},
+			want:    `0`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := Query(dir, tc.program, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.ReplaceAll(string(b), dir, "DIR"); got != tc.want {
+				t.Errorf("Query(%q) = %s, want %s", tc.program, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("---\ntitle: A\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		opts    []Option
+	}{
+		{name: "syntax", program: `[for f in files {`},
+		{name: "unguarded", program: `[for f in files {f.metadata.status}]`},
+		{name: "undefined", program: `documents`},
+		{name: "incomplete", program: `{n: int}`},
+		{name: "invalid expr", program: `files`, opts: []Option{Expr(`title ==`)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Query(dir, tc.program, tc.opts...); err == nil {
+				t.Errorf("Query(%q) = nil, want error", tc.program)
+			}
+		})
+	}
+}
@@ -10,15 +10,26 @@ type Report []*File
 
 // File represents an individual file.
 type File struct {
-	Name     string    `json:"name,omitempty"`     // Name of the file.
-	Metadata cue.Value `json:"metadata,omitzero"`  // File's metadata.
-	Start    int       `json:"start"`              // Line number after the opening delimiter.
-	End      int       `json:"end"`                // Line number before the closing delimiter.
-	Errors   []Error   `json:"errors,omitempty"`   // Any validation errors encountered.
-	Warnings []Error   `json:"warnings,omitempty"` // Any validation warnings encountered.
+	Name       string    `json:"name,omitempty"`       // Name of the file.
+	Metadata   cue.Value `json:"metadata,omitzero"`    // File's metadata.
+	Start      int       `json:"start"`                // Line number after the opening delimiter.
+	End        int       `json:"end"`                  // Line number before the closing delimiter.
+	Errors     []Error   `json:"errors,omitempty"`     // Any validation errors encountered.
+	Warnings   []Error   `json:"warnings,omitempty"`   // Any validation warnings encountered.
+	Findings   []Finding `json:"findings,omitempty"`   // All validation findings encountered, of any severity.
+	Suppressed []Finding `json:"suppressed,omitempty"` // Validation findings suppressed by the file.
 }
 
 type Error struct {
 	Field   string `json:"field,omitempty"`   // Field containing a validaion error.
 	Message string `json:"message,omitempty"` // Validation error.
 }
+
+// Finding represents a validation finding, of any severity.
+type Finding struct {
+	Field    string `json:"field,omitempty"`   // Field containing the finding.
+	Message  string `json:"message,omitempty"` // Description of the finding.
+	Severity string `json:"severity"`          // Name of the severity, such as "error" or "info".
+	Level    int    `json:"level"`             // Level of the severity, with higher levels being more severe.
+	Reason   string `json:"reason,omitempty"`  // Reason for the finding's suppression, if suppressed.
+}
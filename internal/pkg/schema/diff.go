@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	"github.com/slewiskelly/ock/internal/pkg/report"
+	"github.com/slewiskelly/ock/internal/pkg/vet"
+)
+
+// Delta represents the differences between two versions of a schema.
+type Delta struct {
+	Fields []Field  `json:"fields,omitempty"` // Fields of #Metadata which differ, ordered by path.
+	Broken []Impact `json:"broken,omitempty"` // Files which pass validation before, but fail after.
+	Fixed  []Impact `json:"fixed,omitempty"`  // Files which fail validation before, but pass after.
+}
+
+// Field represents the difference of an individual field of #Metadata.
+type Field struct {
+	Path   string `json:"path"`             // Path of the field.
+	Change string `json:"change"`           // One of "added", "removed", "tightened", "loosened" or "changed".
+	Before string `json:"before,omitempty"` // Constraint of the field before, if present.
+	After  string `json:"after,omitempty"`  // Constraint of the field after, if present.
+}
+
+// Impact represents a file whose validity differs between versions.
+type Impact struct {
+	Name   string         `json:"name"`             // Name of the file.
+	Errors []report.Error `json:"errors,omitempty"` // Validation errors, of whichever version fails.
+}
+
+// Diff compares two versions of a schema, a (before) and b (after), reporting
+// the structural differences of their #Metadata definitions, and, if path is
+// not empty, the (markdown) files rooted at path whose validity differs.
+//
+// A field is tightened if every value it allowed before is still allowed, and
+// loosened if the opposite is true. Fields which become required are also
+// considered tightened, and those which become optional loosened.
+func Diff(a, b cue.Value, path string, opts ...Option) (*Delta, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	for _, v := range []cue.Value{a, b} {
+		if err := v.Err(); err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+	}
+
+	d := &Delta{Fields: changes(a, b)}
+
+	if path == "" {
+		return d, nil
+	}
+
+	before, err := vet.Vet(path, a, vet.Glob(o.glob), vet.Level(vet.LvlError))
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := vet.Vet(path, b, vet.Glob(o.glob), vet.Level(vet.LvlError))
+	if err != nil {
+		return nil, err
+	}
+
+	d.Broken, d.Fixed = impacts(before, after), impacts(after, before)
+
+	return d, nil
+}
+
+// decl represents the declaration of a field of #Metadata.
+type decl struct {
+	value    cue.Value
+	optional bool
+}
+
+// changes returns the fields of #Metadata which differ between a and b.
+func changes(a, b cue.Value) []Field {
+	x, y := make(map[string]decl), make(map[string]decl)
+
+	flatten(a.LookupPath(cue.ParsePath("#Metadata")), x)
+	flatten(b.LookupPath(cue.ParsePath("#Metadata")), y)
+
+	var f []Field
+
+	for p, v := range x {
+		w, ok := y[p]
+		if !ok {
+			f = append(f, Field{Path: p, Change: "removed", Before: constraint(v)})
+			continue
+		}
+
+		if c := change(v, w); c != "" {
+			f = append(f, Field{Path: p, Change: c, Before: constraint(v), After: constraint(w)})
+		}
+	}
+
+	for p, w := range y {
+		if _, ok := x[p]; !ok {
+			f = append(f, Field{Path: p, Change: "added", After: constraint(w)})
+		}
+	}
+
+	slices.SortFunc(f, func(a, b Field) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return f
+}
+
+// flatten records each field of v, including structs.
+func flatten(v cue.Value, m map[string]decl) {
+	fields.Walk(v, func(f fields.Field) {
+		m[f.String()] = decl{f.Value, f.Optional}
+	}, cue.Optional(true))
+}
+
+// change returns the kind of change between two declarations of a field, or
+// an empty string if they are equivalent.
+func change(a, b decl) string {
+	tighter, looser := a.optional || !b.optional, b.optional || !a.optional
+
+	// Structs are compared by their fields, and otherwise only by optionality.
+	if a.value.IncompleteKind() != cue.StructKind || b.value.IncompleteKind() != cue.StructKind {
+		tighter = tighter && subsumes(a.value, b.value)
+		looser = looser && subsumes(b.value, a.value)
+	}
+
+	switch {
+	case tighter && looser:
+		return ""
+	case tighter:
+		return "tightened"
+	case looser:
+		return "loosened"
+	default:
+		return "changed"
+	}
+}
+
+// subsumes reports whether a subsumes b, that is, b allows no values which a
+// does not.
+//
+// Validators, such as time.Format, cannot be subsumed, so identical constraints,
+// and conjunctions of a with additional constraints, are also considered. Both
+// are dereferenced, such that references to definitions are compared by the
+// constraints of the definitions, rather than by the references themselves.
+func subsumes(a, b cue.Value) bool {
+	a, b = cue.Dereference(a), cue.Dereference(b)
+
+	if a.Subsume(b) == nil || fmt.Sprint(a) == fmt.Sprint(b) {
+		return true
+	}
+
+	if op, args := b.Expr(); op == cue.AndOp {
+		for _, x := range args {
+			if subsumes(a, x) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// constraint returns the constraint of a field, abbreviating structs, whose
+// fields are compared individually.
+func constraint(d decl) string {
+	c := fmt.Sprint(d.value)
+
+	if d.value.IncompleteKind() == cue.StructKind {
+		c = "{...}"
+	}
+
+	if d.optional {
+		c += " (optional)"
+	}
+
+	return c
+}
+
+// impacts returns the files which failed validation in b, but not in a.
+func impacts(a, b report.Report) []Impact {
+	failed := make(map[string]bool)
+
+	for _, f := range a {
+		if len(f.Errors) > 0 {
+			failed[f.Name] = true
+		}
+	}
+
+	var i []Impact
+
+	for _, f := range b {
+		if len(f.Errors) > 0 && !failed[f.Name] {
+			i = append(i, Impact{Name: f.Name, Errors: f.Errors})
+		}
+	}
+
+	return i
+}
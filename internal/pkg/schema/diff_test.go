@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestDiffChanges(t *testing.T) {
+	ctx := cuecontext.New()
+
+	for _, tc := range []struct {
+		name   string
+		before string
+		after  string
+		defs   [2]string // Definitions, before and after, alongside #Metadata.
+		want   string    // Change of the field "x", or empty if equivalent.
+	}{
+		{name: "equivalent", before: `x: string`, after: `x: string`},
+		{name: "added", before: ``, after: `x: string`, want: "added"},
+		{name: "removed", before: `x: string`, after: ``, want: "removed"},
+		{name: "tightened", before: `x: string`, after: `x: "a" | "b"`, want: "tightened"},
+		{name: "loosened", before: `x: "a" | "b"`, after: `x: string`, want: "loosened"},
+		{name: "changed", before: `x: string`, after: `x: int`, want: "changed"},
+		{name: "required", before: `x?: string`, after: `x: string`, want: "tightened"},
+		{name: "optional", before: `x: string`, after: `x?: string`, want: "loosened"},
+		{name: "optional and tightened", before: `x?: string`, after: `x: "a"`, want: "tightened"},
+		{name: "optional and narrowed", before: `x: string`, after: `x?: "a"`, want: "changed"},
+		{name: "validator", before: `x: time.Format(time.RFC3339Date)`, after: `x: time.Format(time.RFC3339Date)`},
+		{name: "validator tightened", before: `x: time.Format(time.RFC3339Date)`, after: `x: time.Format(time.RFC3339Date) & >="2024-01-01"`, want: "tightened"},
+		{name: "validator loosened", before: `x: time.Format(time.RFC3339Date) & >="2024-01-01"`, after: `x: time.Format(time.RFC3339Date)`, want: "loosened"},
+		{name: "definition", before: `x: #D`, after: `x: #D`, defs: [2]string{`#D: string`, `#D: string`}},
+		{name: "definition tightened", before: `x: #D`, after: `x: #D`, defs: [2]string{`#D: time.Format(time.RFC3339Date)`, `#D: time.Format(time.RFC3339Date) & >="2024-01-01"`}, want: "tightened"},
+		{name: "definition loosened", before: `x: #D`, after: `x: #D`, defs: [2]string{`#D: time.Format(time.RFC3339Date) & >="2024-01-01"`, `#D: time.Format(time.RFC3339Date)`}, want: "loosened"},
+		{name: "definition changed", before: `x: #D`, after: `x: #D`, defs: [2]string{`#D: string`, `#D: int`}, want: "changed"},
+		{name: "definition inlined", before: `x: #D`, after: `x: time.Format(time.RFC3339Date)`, defs: [2]string{`#D: time.Format(time.RFC3339Date)`, ``}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			compile := func(s, defs string) string {
+				return "import \"time\"\n_now: time.Now\n#Metadata: {\n" + s + "\n}\n" + defs + "\n"
+			}
+
+			a, b := ctx.CompileString(compile(tc.before, tc.defs[0])), ctx.CompileString(compile(tc.after, tc.defs[1]))
+
+			for _, v := range []error{a.Err(), b.Err()} {
+				if v != nil {
+					t.Fatal(v)
+				}
+			}
+
+			var got string
+
+			for _, f := range changes(a, b) {
+				if f.Path == "x" {
+					got = f.Change
+				}
+			}
+
+			if got != tc.want {
+				t.Errorf("changes() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
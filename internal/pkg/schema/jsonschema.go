@@ -0,0 +1,386 @@
+// Package schema provides functionality to work with schemas.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// JSONSchema converts the #Metadata definition of the given schema into a JSON
+// Schema (draft 2020-12).
+//
+// Basic types, definitions, disjunctions, defaults, closedness, numeric bounds,
+// regular expressions and the most common validators (such as time.Format,
+// strings.MinRunes and list.MinItems) are converted. Constraints which cannot
+// be expressed in JSON Schema, such as bounds on strings, are omitted.
+func JSONSchema(schema cue.Value) ([]byte, error) {
+	if err := schema.Err(); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	v := schema.LookupPath(cue.ParsePath("#Metadata"))
+	if !v.Exists() {
+		return nil, fmt.Errorf("invalid schema: #Metadata is not defined")
+	}
+
+	o := &object{}
+
+	o.set("$schema", "https://json-schema.org/draft/2020-12/schema")
+	o.set("title", "Metadata")
+
+	constrain(o, v, 0)
+
+	return json.MarshalIndent(o, "", "  ")
+}
+
+// maxDepth limits the depth of conversion, guarding against recursive
+// definitions.
+const maxDepth = 32
+
+func convert(v cue.Value, depth int) *object {
+	o := &object{}
+
+	if d := doc(v); d != "" {
+		o.set("description", d)
+	}
+
+	if d, ok := v.Default(); ok && !v.IsConcrete() && d.Kind()&(cue.StructKind|cue.ListKind) == 0 {
+		o.set("default", value(d))
+	}
+
+	constrain(o, v, depth)
+
+	return o
+}
+
+// constrain adds the constraints of v to o.
+func constrain(o *object, v cue.Value, depth int) {
+	if depth > maxDepth {
+		return
+	}
+
+	op, args := v.Expr()
+
+	switch op {
+	case cue.SelectorOp: // Reference.
+		constrain(o, cue.Dereference(v), depth+1)
+	case cue.AndOp:
+		for _, a := range args {
+			constrain(o, a, depth+1)
+		}
+	case cue.OrOp:
+		disjunction(o, args, depth)
+	case cue.CallOp:
+		builtin(o, args[0], args[1:])
+	case cue.GreaterThanOp, cue.GreaterThanEqualOp, cue.LessThanOp, cue.LessThanEqualOp:
+		bound(o, op, args[0])
+	case cue.NotEqualOp:
+		o.set("not", &object{keys: []string{"const"}, vals: map[string]any{"const": value(args[0])}})
+	case cue.RegexMatchOp:
+		if s, err := args[0].String(); err == nil {
+			o.set("type", "string")
+			o.set("pattern", s)
+		}
+	default:
+		base(o, v, depth)
+	}
+}
+
+func disjunction(o *object, args []cue.Value, depth int) {
+	// Concrete values which are subsumed by other disjuncts, as is typical of
+	// defaults (*false | bool), add no constraints.
+	var d []cue.Value
+
+	for i, a := range args {
+		subsumed := false
+
+		for j, b := range args {
+			if i != j && a.IsConcrete() && !b.IsConcrete() && b.Subsume(a) == nil {
+				subsumed = true
+				break
+			}
+		}
+
+		if !subsumed {
+			d = append(d, a)
+		}
+	}
+
+	if len(d) == 1 {
+		constrain(o, d[0], depth+1)
+		return
+	}
+
+	var enum []any
+
+	for _, a := range d {
+		if !a.IsConcrete() || a.Kind()&(cue.StructKind|cue.ListKind) != 0 {
+			enum = nil
+			break
+		}
+
+		enum = append(enum, value(a))
+	}
+
+	if enum != nil {
+		if k := d[0].Kind(); !slices.ContainsFunc(d, func(a cue.Value) bool { return a.Kind() != k }) {
+			o.set("type", types(k)[0])
+		}
+
+		o.set("enum", enum)
+
+		return
+	}
+
+	var anyOf []*object
+
+	for _, a := range d {
+		anyOf = append(anyOf, convert(a, depth+1))
+	}
+
+	o.set("anyOf", anyOf)
+}
+
+func builtin(o *object, fn cue.Value, args []cue.Value) {
+	var n int64
+
+	if len(args) > 0 {
+		n, _ = args[0].Int64()
+	}
+
+	// Builtins without arguments, such as list.UniqueItems(), are formatted
+	// with their (empty) arguments.
+	switch strings.TrimSuffix(fmt.Sprint(fn), "()") {
+	case "list.MaxItems":
+		o.set("type", "array")
+		o.set("maxItems", n)
+	case "list.MinItems":
+		o.set("type", "array")
+		o.set("minItems", n)
+	case "list.UniqueItems":
+		o.set("type", "array")
+		o.set("uniqueItems", true)
+	case "strings.MaxRunes":
+		o.set("type", "string")
+		o.set("maxLength", n)
+	case "strings.MinRunes":
+		o.set("type", "string")
+		o.set("minLength", n)
+	case "struct.MaxFields":
+		o.set("type", "object")
+		o.set("maxProperties", n)
+	case "struct.MinFields":
+		o.set("type", "object")
+		o.set("minProperties", n)
+	case "time.Format":
+		o.set("type", "string")
+
+		l, _ := args[0].String()
+
+		switch l {
+		case "2006-01-02":
+			o.set("format", "date")
+		case "15:04:05":
+			o.set("format", "time")
+		case "2006-01-02T15:04:05Z07:00", "2006-01-02T15:04:05.999999999Z07:00":
+			o.set("format", "date-time")
+		}
+	case "time.Time":
+		o.set("type", "string")
+		o.set("format", "date-time")
+	}
+}
+
+func bound(o *object, op cue.Op, v cue.Value) {
+	// Bounds constrain the type of the value, unless otherwise constrained,
+	// such as to integers.
+	if _, ok := o.vals["type"]; !ok {
+		switch k := v.Kind(); {
+		case k&cue.NumberKind != 0:
+			o.set("type", "number")
+		case k == cue.StringKind:
+			o.set("type", "string")
+		}
+	}
+
+	if v.Kind()&cue.NumberKind == 0 {
+		return // Bounds on strings cannot be expressed.
+	}
+
+	switch op {
+	case cue.GreaterThanOp:
+		o.set("exclusiveMinimum", value(v))
+	case cue.GreaterThanEqualOp:
+		o.set("minimum", value(v))
+	case cue.LessThanOp:
+		o.set("exclusiveMaximum", value(v))
+	case cue.LessThanEqualOp:
+		o.set("maximum", value(v))
+	}
+}
+
+func base(o *object, v cue.Value, depth int) {
+	k := v.IncompleteKind()
+
+	if v.IsConcrete() && k&(cue.StructKind|cue.ListKind) == 0 {
+		o.set("const", value(v))
+		return
+	}
+
+	switch t := types(k); {
+	case len(t) == 0 || k == cue.TopKind:
+		return
+	case len(t) == 1:
+		o.set("type", t[0])
+	default:
+		o.set("type", t)
+	}
+
+	if k == cue.ListKind {
+		if e := v.LookupPath(cue.MakePath(cue.AnyIndex)); e.Exists() {
+			o.set("items", convert(e, depth+1))
+		}
+	}
+
+	if k == cue.StructKind {
+		properties(o, v, depth)
+	}
+}
+
+// types returns the JSON Schema types corresponding to the given kind.
+func types(k cue.Kind) []string {
+	var types []string
+
+	for _, t := range []struct {
+		kind cue.Kind
+		name string
+	}{
+		{cue.NullKind, "null"},
+		{cue.BoolKind, "boolean"},
+		{cue.IntKind, "integer"},
+		{cue.FloatKind, "number"},
+		{cue.StringKind, "string"},
+		{cue.ListKind, "array"},
+		{cue.StructKind, "object"},
+	} {
+		if k&t.kind != 0 {
+			types = append(types, t.name)
+		}
+	}
+
+	// Integers are also numbers.
+	if k&cue.NumberKind == cue.NumberKind {
+		types = slices.DeleteFunc(types, func(t string) bool { return t == "integer" })
+	}
+
+	return types
+}
+
+func properties(o *object, v cue.Value, depth int) {
+	props := &object{}
+
+	var required []string
+
+	i, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return
+	}
+
+	for i.Next() {
+		name := i.Selector().Unquoted()
+
+		props.set(name, convert(i.Value(), depth+1))
+
+		if !i.IsOptional() {
+			required = append(required, name)
+		}
+	}
+
+	if len(props.keys) > 0 {
+		o.set("properties", props)
+	}
+
+	if len(required) > 0 {
+		o.set("required", required)
+	}
+
+	if p := v.LookupPath(cue.MakePath(cue.AnyString)); p.Exists() {
+		o.set("additionalProperties", convert(p, depth+1))
+	} else if !v.Allows(cue.AnyString) {
+		o.set("additionalProperties", false)
+	}
+}
+
+func doc(v cue.Value) string {
+	var s []string
+
+	for _, c := range v.Doc() {
+		s = append(s, strings.TrimSpace(c.Text()))
+	}
+
+	return strings.Join(s, "\n")
+}
+
+func value(v cue.Value) any {
+	var x any
+
+	if err := v.Decode(&x); err != nil {
+		return nil
+	}
+
+	return x
+}
+
+// object is a JSON object, which retains the order of its keys.
+type object struct {
+	keys []string
+	vals map[string]any
+}
+
+func (o *object) set(k string, v any) {
+	if o.vals == nil {
+		o.vals = make(map[string]any)
+	}
+
+	if _, ok := o.vals[k]; !ok {
+		o.keys = append(o.keys, k)
+	}
+
+	o.vals[k] = v
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := json.Marshal(o.vals[k])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
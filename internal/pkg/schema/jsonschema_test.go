@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestJSONSchema(t *testing.T) {
+	// Common prefix of each (compacted) JSON Schema.
+	const head = `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"Metadata","type":"object",`
+
+	for _, tc := range []struct {
+		name   string
+		schema string
+		want   string // Compacted JSON Schema, following the head.
+	}{
+		{
+			name:   "required and optional",
+			schema: `#Metadata: {title: string, summary?: string}`,
+			want:   `"properties":{"title":{"type":"string"},"summary":{"type":"string"}},"required":["title"],"additionalProperties":false}`,
+		},
+		{
+			name:   "open",
+			schema: `#Metadata: {title: string, ...}`,
+			want:   `"properties":{"title":{"type":"string"}},"required":["title"],"additionalProperties":{}}`,
+		},
+		{
+			name:   "disjunctions",
+			schema: `#Metadata: {status: "draft" | "published", mixed: "a" | 1, value: int | string, n: null | string}`,
+			want:   `"properties":{"status":{"type":"string","enum":["draft","published"]},"mixed":{"enum":["a",1]},"value":{"anyOf":[{"type":"integer"},{"type":"string"}]},"n":{"anyOf":[{"const":null},{"type":"string"}]}},"required":["status","mixed","value","n"],"additionalProperties":false}`,
+		},
+		{
+			name:   "defaults",
+			schema: `#Metadata: {draft: *false | bool, priority: *3 | int, status: *"draft" | "published"}`,
+			want:   `"properties":{"draft":{"default":false,"type":"boolean"},"priority":{"default":3,"type":"integer"},"status":{"default":"draft","type":"string","enum":["draft","published"]}},"required":["draft","priority","status"],"additionalProperties":false}`,
+		},
+		{
+			name:   "lists",
+			schema: `import "list", #Metadata: {tags: [...string] & list.MinItems(1) & list.MaxItems(5) & list.UniqueItems(), related?: [...{title: string, url?: string}]}`,
+			want:   `"properties":{"tags":{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":5,"uniqueItems":true},"related":{"type":"array","items":{"type":"object","properties":{"title":{"type":"string"},"url":{"type":"string"}},"required":["title"],"additionalProperties":false}}},"required":["tags"],"additionalProperties":false}`,
+		},
+		{
+			name:   "nested",
+			schema: `#Metadata: {meta: {owner: string, reviewed?: string}, labels?: {[string]: string}}`,
+			want:   `"properties":{"meta":{"type":"object","properties":{"owner":{"type":"string"},"reviewed":{"type":"string"}},"required":["owner"],"additionalProperties":false},"labels":{"type":"object","additionalProperties":{"type":"string"}}},"required":["meta"],"additionalProperties":false}`,
+		},
+		{
+			name:   "definitions",
+			schema: `#Metadata: {owner: #Owner}, #Owner: =~"^@"`,
+			want:   `"properties":{"owner":{"type":"string","pattern":"^@"}},"required":["owner"],"additionalProperties":false}`,
+		},
+		{
+			name: "validators",
+			schema: `import ("strings", "time")
+#Metadata: {
+	// Date of the last review.
+	reviewed: string & time.Format("2006-01-02")
+	title:    strings.MinRunes(1) & strings.MaxRunes(80)
+}`,
+			want: `"properties":{"reviewed":{"description":"Date of the last review.","type":"string","format":"date"},"title":{"type":"string","minLength":1,"maxLength":80}},"required":["reviewed","title"],"additionalProperties":false}`,
+		},
+		{
+			name:   "bounds",
+			schema: `#Metadata: {weight: >=0 & <100, priority: int & >0, code: >"a", kind: !="x", any: _}`,
+			want:   `"properties":{"weight":{"type":"number","minimum":0,"exclusiveMaximum":100},"priority":{"type":"integer","exclusiveMinimum":0},"code":{"type":"string"},"kind":{"not":{"const":"x"}},"any":{}},"required":["weight","priority","code","kind","any"],"additionalProperties":false}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := JSONSchema(cuecontext.New().CompileString(tc.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+
+			if err := json.Compact(&got, b); err != nil {
+				t.Fatal(err)
+			}
+
+			if want := head + tc.want; got.String() != want {
+				t.Errorf("JSONSchema() = %s, want %s", got.String(), want)
+			}
+		})
+	}
+
+	t.Run("undefined", func(t *testing.T) {
+		if _, err := JSONSchema(cuecontext.New().CompileString(`#Other: string`)); err == nil {
+			t.Error("JSONSchema() = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+	"github.com/slewiskelly/ock/internal/pkg/vet"
+)
+
+// Lint checks the given schema for mistakes which would otherwise either cause
+// validation to fail confusingly, or be silently ignored.
+//
+// Errors are reported for a missing #Metadata definition, for definitions or
+// fields which do not compile, such as empty disjunctions, and for fields with
+// multiple, or invalid, severity attributes (@error, @warning, @notice, @info,
+// or @severity), or with an invalid @path kind, or @owner argument. Warnings
+// are reported for severity attributes which are empty, and for any attributes
+// (including @path, @ref and @owner) which are otherwise ignored, that is,
+// attached to definitions or structs rather than to their fields.
+func Lint(schema cue.Value) (errs, wrns []report.Error) {
+	i, err := schema.Fields(cue.Definitions(true), cue.Optional(true))
+	if err != nil {
+		return []report.Error{{Message: message(err)}}, nil
+	}
+
+	for i.Next() {
+		v := i.Value()
+		p := v.Path().String()
+
+		for _, a := range attributeNames {
+			if has(v, a) {
+				wrns = append(wrns, report.Error{Field: p, Message: fmt.Sprintf("@%s attribute is ignored on definitions, attach it to fields of #Metadata instead", a)})
+			}
+		}
+
+		e, w := lint(v, p == "#Metadata")
+
+		errs = append(errs, e...)
+		wrns = append(wrns, w...)
+	}
+
+	switch err := schema.Validate(); {
+	case err != nil && len(errs) == 0:
+		errs = append(errs, report.Error{Message: message(err)})
+	case !schema.LookupPath(cue.ParsePath("#Metadata")).Exists():
+		errs = append(errs, report.Error{Message: "#Metadata is not defined"})
+	}
+
+	return errs, wrns
+}
+
+// lint checks v, and its fields, for errors. Attributes are only checked if
+// attrs is true, as they are only respected within #Metadata.
+func lint(v cue.Value, attrs bool) (errs, wrns []report.Error) {
+	p := v.Path().String()
+
+	i, err := v.Fields(cue.Optional(true))
+	if err != nil || !vet.Nested(v, cue.Optional(true)) {
+		if err := v.Validate(); err != nil {
+			return []report.Error{{Field: p, Message: message(err)}}, nil
+		}
+
+		if attrs {
+			return attributes(v)
+		}
+
+		return nil, nil
+	}
+
+	for i.Next() {
+		x := i.Value()
+
+		// Fields of structs are validated individually, ignoring any attributes
+		// of the struct itself.
+		if vet.Nested(x, cue.Optional(true)) {
+			for _, a := range attributeNames {
+				if attrs && has(x, a) {
+					wrns = append(wrns, report.Error{Field: x.Path().String(), Message: fmt.Sprintf("@%s attribute is ignored on structs, attach it to fields of the struct instead", a)})
+				}
+			}
+		}
+
+		e, w := lint(x, attrs)
+
+		errs = append(errs, e...)
+		wrns = append(wrns, w...)
+	}
+
+	// Errors of the struct itself, rather than any of its fields, such as
+	// fields not being allowed.
+	if err := v.Validate(); err != nil && len(errs) == 0 {
+		errs = append(errs, report.Error{Field: p, Message: message(err)})
+	}
+
+	return errs, wrns
+}
+
+// severities are the names of attributes specifying the severity of a field's
+// validation errors.
+var severities = []string{"error", "warning", "notice", "info", "severity"}
+
+// attributeNames are the names of all attributes respected on fields of
+// #Metadata.
+var attributeNames = append(slices.Clone(severities), "path", "ref", "owner")
+
+// attributes checks the severity attributes of a (non-struct) field.
+func attributes(v cue.Value) (errs, wrns []report.Error) {
+	p := v.Path().String()
+
+	var n []string
+
+	for _, s := range severities {
+		if has(v, s) {
+			n = append(n, "@"+s)
+		}
+	}
+
+	if len(n) > 1 {
+		errs = append(errs, report.Error{Field: p, Message: fmt.Sprintf("field has multiple severity attributes (%s), only one is respected", strings.Join(n, ", "))})
+	}
+
+	for _, s := range severities {
+		a := v.Attribute(s)
+		if a.Err() != nil {
+			continue
+		}
+
+		msg := a.Contents()
+
+		if s == "severity" {
+			l, m, _ := strings.Cut(msg, ",")
+
+			if _, err := vet.ParseLvl(strings.TrimSpace(l)); err != nil {
+				errs = append(errs, report.Error{Field: p, Message: fmt.Sprintf("@severity attribute has an %v, expected a name (info, notice, warning or error) or a positive number", err)})
+			}
+
+			msg = strings.TrimSpace(m)
+		}
+
+		if msg == "" && s != "severity" {
+			wrns = append(wrns, report.Error{Field: p, Message: fmt.Sprintf("@%s attribute has no message", s)})
+		}
+	}
+
+	if a := v.Attribute("path"); a.Err() == nil && a.Contents() != "" && !slices.Contains(vet.PathKinds, a.Contents()) {
+		errs = append(errs, report.Error{Field: p, Message: fmt.Sprintf("@path attribute has an invalid kind %q, expected one of %s", a.Contents(), strings.Join(vet.PathKinds, ", "))})
+	}
+
+	if a := v.Attribute("owner"); a.Err() == nil && a.Contents() != "" && a.Contents() != "codeowners" {
+		errs = append(errs, report.Error{Field: p, Message: fmt.Sprintf("@owner attribute has an invalid argument %q, expected none, or codeowners", a.Contents())})
+	}
+
+	return errs, wrns
+}
+
+// has reports whether v has an attribute of the given name.
+func has(v cue.Value, name string) bool {
+	a := v.Attribute(name)
+	return a.Err() == nil
+}
+
+// message returns the message, including positions, of each error.
+func message(err error) string {
+	var msgs []string
+
+	for _, e := range errors.Errors(err) {
+		f, a := e.Msg()
+		m := fmt.Sprintf(f, a...)
+
+		pos := e.Position()
+		if p := e.InputPositions(); !pos.IsValid() && len(p) > 0 {
+			pos = p[0]
+		}
+
+		if pos.IsValid() {
+			m = fmt.Sprintf("%s (%s)", m, pos)
+		}
+
+		msgs = append(msgs, m)
+	}
+
+	return strings.Join(msgs, "\n")
+}
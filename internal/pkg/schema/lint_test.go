@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+func TestLint(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		schema string
+		errs   []string // Field, and prefix of the message, of each error.
+		wrns   []string // Field, and prefix of the message, of each warning.
+	}{
+		{
+			name:   "valid",
+			schema: `#Metadata: {title: string @error(too short), image?: string @path(file), doc?: string @ref(md), owner: string @owner(codeowners), n: int @severity(6, too many)}`,
+		},
+		{
+			name:   "missing #Metadata",
+			schema: `#Other: string`,
+			errs:   []string{": #Metadata is not defined"},
+		},
+		{
+			name:   "empty disjunction",
+			schema: `#Metadata: {status: ("draft" | "published") & "archived"}`,
+			errs:   []string{"#Metadata.status: 2 errors in empty disjunction"},
+		},
+		{
+			name:   "conflicting values",
+			schema: `#Metadata: {status: "draft" & "published"}`,
+			errs:   []string{`#Metadata.status: conflicting values "published" and "draft"`},
+		},
+		{
+			name:   "multiple severities",
+			schema: `#Metadata: {title: string @error(x) @warning(y)}`,
+			errs:   []string{"#Metadata.title: field has multiple severity attributes (@error, @warning)"},
+		},
+		{
+			name:   "multiple severities nested",
+			schema: `#Metadata: {meta: {n: int @info(x) @severity(6, y)}}`,
+			errs:   []string{"#Metadata.meta.n: field has multiple severity attributes (@info, @severity)"},
+		},
+		{
+			name:   "invalid severity",
+			schema: `#Metadata: {title: string @severity(bad, x)}`,
+			errs:   []string{`#Metadata.title: @severity attribute has an invalid level "bad"`},
+		},
+		{
+			name:   "empty severity",
+			schema: `#Metadata: {title: string @warning()}`,
+			wrns:   []string{"#Metadata.title: @warning attribute has no message"},
+		},
+		{
+			name:   "invalid path kind",
+			schema: `#Metadata: {image: string @path(files)}`,
+			errs:   []string{`#Metadata.image: @path attribute has an invalid kind "files"`},
+		},
+		{
+			name:   "invalid owner argument",
+			schema: `#Metadata: {owner: string @owner(github)}`,
+			errs:   []string{`#Metadata.owner: @owner attribute has an invalid argument "github"`},
+		},
+		{
+			name:   "ignored on definitions",
+			schema: `#Metadata: {owner: #Owner}, #Owner: string @owner() @warning(x)`,
+			wrns: []string{
+				"#Owner: @warning attribute is ignored on definitions",
+				"#Owner: @owner attribute is ignored on definitions",
+			},
+		},
+		{
+			name:   "ignored on structs",
+			schema: `#Metadata: {meta: {owner: string} @warning(x)}`,
+			wrns:   []string{"#Metadata.meta: @warning attribute is ignored on structs"},
+		},
+		{
+			name:   "ignored outside #Metadata",
+			schema: `#Metadata: {}, #Other: {title: string @error(x) @warning(y)}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs, wrns := Lint(cuecontext.New().CompileString(tc.schema))
+
+			for _, x := range []struct {
+				kind string
+				got  []report.Error
+				want []string
+			}{{"errors", errs, tc.errs}, {"warnings", wrns, tc.wrns}} {
+				if len(x.got) != len(x.want) {
+					t.Errorf("Lint() %s = %q, want %q", x.kind, x.got, x.want)
+					continue
+				}
+
+				for i, e := range x.got {
+					if got := e.Field + ": " + e.Message; !strings.HasPrefix(got, x.want[i]) {
+						t.Errorf("Lint() %s[%d] = %q, want prefix %q", x.kind, i, got, x.want[i])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package schema
+
+// Option is an option to Diff.
+type Option interface {
+	apply(*options)
+}
+
+// Glob specifies a pattern to filter files that are attempted to be validated.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+type options struct {
+	glob string
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
@@ -0,0 +1,228 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/cue/token"
+	"cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/jsonschema"
+	"cuelang.org/go/encoding/openapi"
+	"cuelang.org/go/encoding/yaml"
+)
+
+// Load loads the schema from the given file.
+//
+// CUE files are loaded as is, whereas JSON Schema and OpenAPI files (.json,
+// .yaml or .yml) are first imported, see Import.
+func Load(filename string) (cue.Value, error) {
+	if !imported(filename) {
+		i := load.Instances([]string{filename}, nil)[0]
+		if err := i.Err; err != nil {
+			return cue.Value{}, err
+		}
+
+		return cuecontext.New().BuildInstance(i), nil
+	}
+
+	f, err := extract(filename)
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	return cuecontext.New().BuildFile(f), nil
+}
+
+// Import converts the JSON Schema, or OpenAPI, definition in the given file
+// into a CUE schema, returning its source.
+//
+// The root of a JSON Schema becomes the #Metadata definition, and any other
+// definitions ($defs) become definitions of the same name. Similarly, OpenAPI
+// components become definitions of the same name, with a component named
+// "Metadata", or the only component, becoming the #Metadata definition.
+//
+// A different OpenAPI component may be used as #Metadata by appending its name
+// to the filename, for example "openapi.yaml#Frontmatter".
+func Import(filename string) ([]byte, error) {
+	f, err := extract(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Node(f, format.Simplify())
+}
+
+// imported reports whether the given file must be imported, rather than being
+// loaded as CUE.
+func imported(filename string) bool {
+	name, _, _ := strings.Cut(filename, "#")
+
+	switch filepath.Ext(name) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+func extract(filename string) (*ast.File, error) {
+	name, def, _ := strings.Cut(filename, "#")
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var v cue.Value
+
+	switch filepath.Ext(name) {
+	case ".json":
+		e, err := json.Extract(name, b)
+		if err != nil {
+			return nil, err
+		}
+
+		v = cuecontext.New().BuildExpr(e)
+	default:
+		f, err := yaml.Extract(name, b)
+		if err != nil {
+			return nil, err
+		}
+
+		v = cuecontext.New().BuildFile(f)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	var f *ast.File
+
+	if v.LookupPath(cue.ParsePath("openapi")).Exists() {
+		f, err = fromOpenAPI(name, v, def)
+	} else {
+		f, err = fromJSONSchema(name, v, def)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Required fields are declared as regular fields, as is conventional for
+	// schemas, such that their absence is reported as an incomplete value.
+	ast.Walk(f, func(n ast.Node) bool {
+		if x, ok := n.(*ast.Field); ok && x.Constraint == token.NOT {
+			x.Constraint = token.ILLEGAL
+		}
+		return true
+	}, nil)
+
+	return f, nil
+}
+
+func fromJSONSchema(name string, v cue.Value, def string) (*ast.File, error) {
+	if def != "" {
+		return nil, fmt.Errorf("%s: definitions can only be selected from OpenAPI components", name)
+	}
+
+	f, err := jsonschema.Extract(v, &jsonschema.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	// The root schema is declared at the top level, alongside definitions,
+	// and is moved into the #Metadata definition.
+	var decls, defs, root []ast.Decl
+
+	for _, d := range f.Decls {
+		switch x := d.(type) {
+		case *ast.EmbedDecl, *ast.Ellipsis:
+			root = append(root, x)
+		case *ast.Field:
+			if definition(x) {
+				defs = append(defs, x)
+			} else {
+				root = append(root, x)
+			}
+		default:
+			decls = append(decls, x)
+		}
+	}
+
+	f.Decls = append(append(decls, field("#Metadata", &ast.StructLit{Elts: root})), defs...)
+
+	return f, nil
+}
+
+func fromOpenAPI(name string, v cue.Value, def string) (*ast.File, error) {
+	f, err := openapi.Extract(v, &openapi.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var (
+		decls []ast.Decl
+		defs  []string
+	)
+
+	for _, d := range f.Decls {
+		if x, ok := d.(*ast.Field); ok {
+			if !definition(x) {
+				continue // Informational fields, such as info.
+			}
+
+			n, _, _ := ast.LabelName(x.Label)
+			defs = append(defs, strings.TrimPrefix(n, "#"))
+		}
+
+		decls = append(decls, d)
+	}
+
+	f.Decls = decls
+
+	switch {
+	case def != "" && !slices.Contains(defs, def):
+		return nil, fmt.Errorf("%s: no such component %q, specify one of: %s", name, def, strings.Join(defs, ", "))
+	case def != "":
+	case slices.Contains(defs, "Metadata"):
+		return f, nil
+	case len(defs) == 1:
+		def = defs[0]
+	default:
+		return nil, fmt.Errorf("%s: unable to determine which component defines the metadata, specify one of: %s", name, strings.Join(defs, ", "))
+	}
+
+	return metadata(name, f, def)
+}
+
+// metadata declares the given definition as the #Metadata definition.
+func metadata(name string, f *ast.File, def string) (*ast.File, error) {
+	if !ast.IsValidIdent("#" + def) {
+		return nil, fmt.Errorf("%s: invalid definition name %q", name, def)
+	}
+
+	f.Decls = append(f.Decls, field("#Metadata", ast.NewIdent("#"+def)))
+
+	return f, nil
+}
+
+func field(label string, v ast.Expr) *ast.Field {
+	f := &ast.Field{Label: ast.NewIdent(label), Value: v}
+
+	ast.SetRelPos(f, token.NewSection)
+
+	return f
+}
+
+func definition(f *ast.Field) bool {
+	n, _, _ := ast.LabelName(f.Label)
+	return strings.HasPrefix(n, "#")
+}
@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+)
+
+const openAPI = `openapi: 3.0.0
+info:
+  title: Docs
+  version: 1.0.0
+components:
+  schemas:
+    Frontmatter:
+      type: object
+      properties:
+        title:
+          type: string
+    Owner:
+      type: string
+`
+
+func TestImport(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "openapi.yaml")
+
+	if err := os.WriteFile(name, []byte(openAPI), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		def  string
+		want string // Substring of the source, or the error.
+		err  bool
+	}{
+		{def: "Frontmatter", want: "#Metadata: #Frontmatter"},
+		{def: "Nope", want: `no such component "Nope"`, err: true},
+		{def: "", want: "unable to determine which component", err: true},
+	} {
+		t.Run(tc.def, func(t *testing.T) {
+			filename := name
+			if tc.def != "" {
+				filename += "#" + tc.def
+			}
+
+			b, err := Import(filename)
+			if (err != nil) != tc.err {
+				t.Fatalf("Import(%q) = %v, want error: %v", filename, err, tc.err)
+			}
+
+			got := string(b)
+			if err != nil {
+				got = err.Error()
+			}
+
+			if !strings.Contains(got, tc.want) {
+				t.Errorf("Import(%q) = %q, want to contain %q", filename, got, tc.want)
+			}
+		})
+	}
+}
+
+const jsonSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["title"],
+  "properties": {
+    "title": {"type": "string"},
+    "owner": {"$ref": "#/$defs/owner"}
+  },
+  "$defs": {
+    "owner": {"type": "string"}
+  }
+}`
+
+func TestImportJSONSchema(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "metadata.schema.json")
+
+	if err := os.WriteFile(name, []byte(jsonSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Import(name)
+	if err != nil {
+		t.Fatalf("Import() = %v", err)
+	}
+
+	v, err := Load(name)
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+
+	for _, tc := range []struct {
+		metadata string
+		valid    bool
+	}{
+		{`{title: "A"}`, true},
+		{`{title: "A", owner: "alice"}`, true},
+		{`{title: "A", extra: 1}`, true}, // Additional properties are allowed by default.
+		{`{owner: "alice"}`, false},
+		{`{title: 1}`, false},
+	} {
+		m := v.Context().CompileString(tc.metadata)
+		err := m.Unify(v.LookupPath(cue.ParsePath("#Metadata"))).Validate(cue.Concrete(true))
+
+		if (err == nil) != tc.valid {
+			t.Errorf("Import() validating %s = %v, want valid: %v\n%s", tc.metadata, err, tc.valid, b)
+		}
+	}
+
+	// The ellipsis of the root schema is declared within #Metadata, rather than
+	// at the top level.
+	if s := strings.TrimSpace(string(b)); strings.HasSuffix(s, "...") {
+		t.Errorf("Import() = %s, want no top-level ellipsis", b)
+	}
+
+	if _, err := Import(name + "#owner"); err == nil {
+		t.Errorf("Import(%q) = nil, want error", name+"#owner")
+	}
+}
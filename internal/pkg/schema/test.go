@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/encoding/yaml"
+
+	"github.com/slewiskelly/ock/internal/pkg/frontmatter"
+	"github.com/slewiskelly/ock/internal/pkg/vet"
+)
+
+// Result represents the result of an individual fixture.
+type Result struct {
+	Name     string   `json:"name"`               // Name of the fixture.
+	Failures []string `json:"failures,omitempty"` // Differences between expected and actual findings.
+}
+
+// Passed reports whether the fixture passed.
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Test validates the fixtures rooted at the given directory against the given
+// schema, comparing the findings with those expected.
+//
+// Each fixture contains example metadata, either as YAML (.yaml or .yml files),
+// or as the frontmatter of a markdown (.md) file, whose body is then provided to
+// the schema's _body field, see vet.BodyField. Markdown files without
+// frontmatter are skipped. Expected findings are declared by comments, within
+// the metadata, of the form:
+//
+//	# <severity>: <field> [message]
+//
+// Where severity is the name (error, warning, notice, or info), or number, of
+// the finding's level.
+//
+// A fixture passes if exactly the expected findings are reported, with messages
+// only being compared if given. A fixture without any expected findings must be
+// valid. Suppressed findings, see vet.Suppressions, are not reported.
+func Test(schema cue.Value, dir string) ([]Result, error) {
+	var r []Result
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() || !slices.Contains([]string{".md", ".yaml", ".yml"}, filepath.Ext(p)) {
+			return nil
+		}
+
+		res, err := test(schema, p)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			r = append(r, *res)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// finding represents an expected, or actual, finding of a fixture.
+type finding struct {
+	lvl     string // Name, or number, of the level.
+	field   string
+	message string // Message, which is not compared if empty.
+}
+
+func (f finding) String() string {
+	if f.message == "" {
+		return fmt.Sprintf("%s: %s", f.lvl, f.field)
+	}
+
+	return fmt.Sprintf("%s: %s %s", f.lvl, f.field, f.message)
+}
+
+var expectation = regexp.MustCompile(`^#\s*(error|warning|notice|info|\d+):\s*(\S+)\s*(.*)$`)
+
+func test(schema cue.Value, p string) (*Result, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+
+	if filepath.Ext(p) == ".md" {
+		d, err := frontmatter.Parse(p, b)
+		if err != nil {
+			return nil, err
+		}
+
+		// Markdown files without frontmatter, such as a README describing the
+		// fixtures, are not fixtures.
+		if d == nil {
+			return nil, nil
+		}
+
+		b, body = d.Data(), d.Body()
+	}
+
+	var want []finding
+
+	for s := bufio.NewScanner(bytes.NewReader(b)); s.Scan(); {
+		if m := expectation.FindStringSubmatch(strings.TrimSpace(s.Text())); m != nil {
+			want = append(want, finding{m[1], m[2], m[3]})
+		}
+	}
+
+	y, err := yaml.Extract(p, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var got []finding
+
+	for _, f := range vet.Findings(cuecontext.New().BuildFile(y), schema, vet.Body(body), vet.Level(vet.LvlInfo), vet.Suppress(vet.Suppressions(b)...)) {
+		got = append(got, finding{f.Severity, f.Field, f.Message})
+	}
+
+	r := &Result{Name: p}
+
+	for _, w := range want {
+		i := slices.IndexFunc(got, func(g finding) bool {
+			return g.lvl == w.lvl && g.field == w.field && (w.message == "" || g.message == w.message)
+		})
+
+		if i < 0 {
+			r.Failures = append(r.Failures, fmt.Sprintf("missing %s", w))
+			continue
+		}
+
+		got = slices.Delete(got, i, i+1)
+	}
+
+	for _, g := range got {
+		r.Failures = append(r.Failures, fmt.Sprintf("unexpected %s", g))
+	}
+
+	return r, nil
+}
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestTest(t *testing.T) {
+	schema := cuecontext.New().CompileString(`
+_body: _
+
+#Metadata: {
+	title:  _body.h1
+	status: "draft" | "published"
+}
+`)
+
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		// YAML fixtures have no body.
+		"valid.yaml":   "# error: title\ntitle: Alpha\nstatus: draft\n",
+		"invalid.yaml": "# error: title\n# error: status\ntitle: Alpha\nstatus: unknown\n",
+		"body.md":      "---\ntitle: Alpha\nstatus: draft\n---\n# Alpha\n",
+		"mismatch.md":  "---\n# error: title\ntitle: Alpha\nstatus: draft\n---\n# Beta\n",
+		"wrong.md":     "---\ntitle: Alpha\nstatus: draft\n---\n# Beta\n",
+		"ignored.txt":  "title: Alpha\n",
+		"README.md":    "# Fixtures\n\nNot a fixture.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := Test(schema, dir)
+	if err != nil {
+		t.Fatalf("Test() = %v", err)
+	}
+
+	var got []string
+
+	for _, x := range r {
+		if !x.Passed() {
+			got = append(got, filepath.Base(x.Name))
+		}
+	}
+
+	if len(r) != 5 {
+		t.Errorf("Test() = %d results, want 5", len(r))
+	}
+
+	if want := []string{"wrong.md"}; !slices.Equal(got, want) {
+		t.Errorf("Test() failed = %q, want %q", got, want)
+	}
+}
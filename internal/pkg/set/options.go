@@ -0,0 +1,53 @@
+package set
+
+import (
+	"cuelang.org/go/cue"
+)
+
+// Option is an option to Set, Unset and Modify.
+type Option interface {
+	apply(*options)
+}
+
+// DryRun specifies that modifications should be computed, but not written.
+func DryRun(b bool) Option {
+	return option(func(o *options) {
+		o.dryRun = b
+	})
+}
+
+// Expr specifies an expression used to filter files.
+func Expr(e string) Option {
+	return option(func(o *options) {
+		o.expr = e
+	})
+}
+
+// Glob specifies a pattern to filter files.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+// Schema specifies a schema which modified metadata is validated against.
+//
+// Files whose modified metadata fails validation are not written.
+func Schema(v cue.Value) Option {
+	return option(func(o *options) {
+		o.schema = &v
+	})
+}
+
+type options struct {
+	dryRun bool
+	expr   string
+	glob   string
+	schema *cue.Value
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
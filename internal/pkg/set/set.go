@@ -0,0 +1,177 @@
+// Package set provides functionality to modify document metadata.
+package set
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/encoding/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/slewiskelly/ock/internal/pkg/frontmatter"
+	"github.com/slewiskelly/ock/internal/pkg/get"
+	"github.com/slewiskelly/ock/internal/pkg/report"
+	"github.com/slewiskelly/ock/internal/pkg/vet"
+)
+
+// Change represents the modification of an individual file.
+type Change struct {
+	Name     string         // Name of the file.
+	Before   []byte         // File's original contents.
+	After    []byte         // File's modified contents.
+	Written  bool           // Whether the modified contents were written.
+	Errors   []report.Error // Any errors modifying, or validating, the metadata.
+	Warnings []report.Error // Any validation warnings of the modified metadata.
+}
+
+// Field represents a field to be set.
+type Field struct {
+	Path  string // Path of the field, with nested fields separated by ".".
+	Value string // Value of the field, expressed in YAML.
+}
+
+// Set sets the given fields in the metadata of (markdown) files rooted at the
+// given path.
+//
+// The returned changes contain only files whose contents were modified, or
+// could not be modified.
+func Set(path string, fields []Field, opts ...Option) ([]Change, error) {
+	values := make([]*yamlv3.Node, len(fields))
+
+	for i, f := range fields {
+		var n yamlv3.Node
+
+		if err := yamlv3.Unmarshal([]byte(f.Value), &n); err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", f.Path, err)
+		}
+
+		if len(n.Content) == 0 {
+			values[i] = &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null", Value: "null"}
+		} else {
+			values[i] = n.Content[0]
+		}
+	}
+
+	return Modify(path, func(n *yamlv3.Node) error {
+		for i, f := range fields {
+			// Each file receives its own copy, as nodes are modified in place.
+			v := *values[i]
+
+			if err := frontmatter.Set(n, strings.Split(f.Path, "."), &v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, opts...)
+}
+
+// Unset removes the given fields from the metadata of (markdown) files rooted
+// at the given path.
+//
+// The returned changes contain only files whose contents were modified, or
+// could not be modified.
+func Unset(path string, fields []string, opts ...Option) ([]Change, error) {
+	return Modify(path, func(n *yamlv3.Node) error {
+		for _, f := range fields {
+			frontmatter.Unset(n, strings.Split(f, "."))
+		}
+
+		return nil
+	}, opts...)
+}
+
+// Modify modifies the metadata of (markdown) files rooted at the given path,
+// with fn modifying each file's metadata in place. Files for which fn returns
+// an error are not modified, with the error being reported by their change.
+//
+// Fields whose values are unchanged retain their original formatting and
+// comments. The returned changes contain only files whose contents were
+// modified, or could not be modified.
+func Modify(path string, fn func(*yamlv3.Node) error, opts ...Option) ([]Change, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	r, err := get.Get(path, get.Expr(o.expr), get.Glob(o.glob))
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	for _, f := range r {
+		c, err := change(f.Name, fn, o)
+		if err != nil {
+			return changes, err
+		}
+
+		if c != nil {
+			changes = append(changes, *c)
+		}
+	}
+
+	return changes, nil
+}
+
+func change(p string, fn func(*yamlv3.Node) error, o *options) (*Change, error) {
+	d, err := frontmatter.Read(p)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	before := d.Bytes()
+
+	n, err := d.Node()
+	if err != nil {
+		return nil, err
+	}
+
+	data := d.Data()
+
+	if err := fn(n); err != nil {
+		return &Change{Name: p, Before: before, Errors: []report.Error{{Message: err.Error()}}}, nil
+	}
+
+	if err := d.SetNode(n); err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, d.Data()) {
+		return nil, nil
+	}
+
+	c := &Change{Name: p, Before: before, After: d.Bytes()}
+
+	if o.schema != nil {
+		y, err := yaml.Extract(p, d.Data())
+		if err != nil {
+			return nil, err
+		}
+
+		c.Errors, c.Warnings = vet.Validate(cuecontext.New().BuildFile(y), *o.schema, vet.Body(d.Body()), vet.Dir(filepath.Dir(p)), vet.Suppress(vet.Suppressions(d.Data())...))
+	}
+
+	if o.dryRun || len(c.Errors) > 0 {
+		return c, nil
+	}
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(p, c.After, fi.Mode().Perm()); err != nil {
+		return nil, err
+	}
+
+	c.Written = true
+
+	return c, nil
+}
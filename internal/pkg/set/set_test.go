@@ -0,0 +1,215 @@
+package set
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+const doc = `---
+# Document metadata.
+title:   "Alpha"   # trailing
+status: draft
+reviewed: 2023-01-01
+meta:
+  owner: alice
+---
+Body.
+`
+
+// write writes the given files to a temporary directory, returning the
+// directory.
+func write(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, s := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func read(t *testing.T, p string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestSet(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		fields []Field
+		want   string
+	}{
+		{
+			name:   "existing",
+			fields: []Field{{"status", "published"}},
+			want:   "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nstatus: published\nreviewed: 2023-01-01\nmeta:\n  owner: alice\n---\nBody.\n",
+		},
+		{
+			name:   "new",
+			fields: []Field{{"tags", "[api, cli]"}, {"meta.team", "docs"}},
+			want:   "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nstatus: draft\nreviewed: 2023-01-01\nmeta:\n  owner: alice\n  team: docs\ntags: [api, cli]\n---\nBody.\n",
+		},
+		{
+			name:   "null",
+			fields: []Field{{"status", ""}},
+			want:   "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nstatus: null\nreviewed: 2023-01-01\nmeta:\n  owner: alice\n---\nBody.\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := write(t, map[string]string{"a.md": doc})
+
+			c, err := Set(dir, tc.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(c) != 1 || !c[0].Written {
+				t.Fatalf("Set() = %+v, want 1 written change", c)
+			}
+
+			if got := read(t, filepath.Join(dir, "a.md")); got != tc.want {
+				t.Errorf("Set() =\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetUnset(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		fields []Field
+	}{
+		{"field", []Field{{"summary", "Hi"}}},
+		{"nested", []Field{{"meta.team", "docs"}}},
+		{"new parent", []Field{{"review.by", "bob"}}},
+		{"new nested parents", []Field{{"review.last.by", "bob"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := write(t, map[string]string{"a.md": doc})
+
+			if _, err := Set(dir, tc.fields); err != nil {
+				t.Fatal(err)
+			}
+
+			var paths []string
+
+			for _, f := range tc.fields {
+				paths = append(paths, f.Path)
+			}
+
+			if _, err := Unset(dir, paths); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := read(t, filepath.Join(dir, "a.md")); got != doc {
+				t.Errorf("Unset(Set()) =\n%s\nwant:\n%s", got, doc)
+			}
+		})
+	}
+}
+
+func TestUnset(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		fields []string
+		want   string // Empty if unchanged.
+	}{
+		{
+			name:   "field",
+			fields: []string{"status"},
+			want:   "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nreviewed: 2023-01-01\nmeta:\n  owner: alice\n---\nBody.\n",
+		},
+		{
+			name:   "last nested",
+			fields: []string{"meta.owner"},
+			want:   "---\n# Document metadata.\ntitle:   \"Alpha\"   # trailing\nstatus: draft\nreviewed: 2023-01-01\n---\nBody.\n",
+		},
+		{
+			name:   "missing",
+			fields: []string{"summary", "meta.team"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := write(t, map[string]string{"a.md": doc})
+
+			c, err := Unset(dir, tc.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tc.want
+			if want == "" {
+				want = doc
+
+				if len(c) != 0 {
+					t.Errorf("Unset() = %+v, want no changes", c)
+				}
+			}
+
+			if got := read(t, filepath.Join(dir, "a.md")); got != want {
+				t.Errorf("Unset() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestModifyOptions(t *testing.T) {
+	schema := cuecontext.New().CompileString(`#Metadata: {
+	title:    string
+	status:   "draft" | "published"
+	reviewed: string
+	meta: owner: string
+}`)
+
+	for _, tc := range []struct {
+		name    string
+		fields  []Field
+		opts    []Option
+		changes int
+		written bool
+		errs    bool
+	}{
+		{name: "dry run", fields: []Field{{"status", "published"}}, opts: []Option{DryRun(true)}, changes: 1},
+		{name: "expr", fields: []Field{{"status", "published"}}, opts: []Option{Expr(`status == "published"`)}},
+		{name: "glob", fields: []Field{{"status", "published"}}, opts: []Option{Glob("**/b.md")}},
+		{name: "valid", fields: []Field{{"status", "published"}}, opts: []Option{Schema(schema)}, changes: 1, written: true},
+		{name: "invalid", fields: []Field{{"status", "archived"}}, opts: []Option{Schema(schema)}, changes: 1, errs: true},
+		{name: "not a mapping", fields: []Field{{"status.by", "bob"}}, changes: 1, errs: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := write(t, map[string]string{"a.md": doc})
+
+			c, err := Set(dir, tc.fields, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(c) != tc.changes {
+				t.Fatalf("Set() = %+v, want %d change(s)", c, tc.changes)
+			}
+
+			for _, x := range c {
+				if x.Written != tc.written || (len(x.Errors) > 0) != tc.errs {
+					t.Errorf("Set() = %+v, want written: %v, errors: %v", x, tc.written, tc.errs)
+				}
+			}
+
+			if got := read(t, filepath.Join(dir, "a.md")); !tc.written && got != doc {
+				t.Errorf("Set() =\n%s\nwant unchanged", got)
+			}
+		})
+	}
+}
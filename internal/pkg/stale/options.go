@@ -0,0 +1,65 @@
+package stale
+
+import (
+	"time"
+)
+
+// Option is an option to Stale.
+type Option interface {
+	apply(*options)
+}
+
+// Expr specifies an expression used to filter files.
+func Expr(e string) Option {
+	return option(func(o *options) {
+		o.expr = e
+	})
+}
+
+// Field specifies the field containing the date a file was last reviewed.
+//
+// By default, "reviewed" is used, falling back to "lastReviewed".
+func Field(path string) Option {
+	return option(func(o *options) {
+		o.fields = []string{path}
+	})
+}
+
+// Glob specifies a pattern to filter files.
+func Glob(pattern string) Option {
+	return option(func(o *options) {
+		o.glob = pattern
+	})
+}
+
+// Now specifies the time against which review dates are compared.
+//
+// By default, the current time is used.
+func Now(t time.Time) Option {
+	return option(func(o *options) {
+		o.now = t
+	})
+}
+
+// Owner specifies the field containing the owner of a file.
+//
+// By default, "owner" is used.
+func Owner(path string) Option {
+	return option(func(o *options) {
+		o.owner = path
+	})
+}
+
+type options struct {
+	expr   string
+	fields []string
+	glob   string
+	now    time.Time
+	owner  string
+}
+
+type option func(*options)
+
+func (o option) apply(opts *options) {
+	o(opts)
+}
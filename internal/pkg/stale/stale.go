@@ -0,0 +1,98 @@
+// Package stale provides functionality to report files due for review.
+package stale
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	"github.com/slewiskelly/ock/internal/pkg/get"
+)
+
+// Group represents the stale files of an individual owner.
+type Group struct {
+	Owner string `json:"owner"` // Owner of the files, empty if unowned.
+	Files []File `json:"files"` // Stale files, ordered from least recently reviewed.
+}
+
+// File represents an individual stale file.
+type File struct {
+	Name     string `json:"name"`               // Name of the file.
+	Reviewed string `json:"reviewed,omitempty"` // Date the file was last reviewed, empty if unknown.
+	Days     int    `json:"days,omitempty"`     // Number of days since the file was last reviewed.
+}
+
+// Stale reports (markdown) files rooted at the given path, which were last
+// reviewed longer ago than the given age, grouped by owner.
+//
+// Files without a (valid) review date are considered stale.
+func Stale(path string, age time.Duration, opts ...Option) ([]Group, error) {
+	o := &options{
+		fields: []string{"reviewed", "lastReviewed"},
+		now:    time.Now(),
+		owner:  "owner",
+	}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	r, err := get.Get(path, get.Expr(o.expr), get.Glob(o.glob))
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string][]File)
+
+	for _, f := range r {
+		x := File{Name: f.Name}
+
+		reviewed, ok := reviewedAt(f.Metadata, o.fields)
+		if ok {
+			if o.now.Sub(reviewed) <= age {
+				continue
+			}
+
+			x.Reviewed = reviewed.Format(time.DateOnly)
+			x.Days = int(o.now.Sub(reviewed).Hours() / 24)
+		}
+
+		owner, _ := f.Metadata.LookupPath(cue.ParsePath(o.owner)).String()
+
+		owners[owner] = append(owners[owner], x)
+	}
+
+	var g []Group
+
+	for owner, files := range owners {
+		slices.SortFunc(files, func(a, b File) int {
+			return cmp.Or(cmp.Compare(a.Reviewed, b.Reviewed), cmp.Compare(a.Name, b.Name))
+		})
+
+		g = append(g, Group{Owner: owner, Files: files})
+	}
+
+	slices.SortFunc(g, func(a, b Group) int {
+		return cmp.Compare(a.Owner, b.Owner)
+	})
+
+	return g, nil
+}
+
+func reviewedAt(v cue.Value, paths []string) (time.Time, bool) {
+	for _, f := range paths {
+		s, err := v.LookupPath(cue.ParsePath(f)).String()
+		if err != nil {
+			continue
+		}
+
+		if t, ok := fields.Date(s); ok {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,98 @@
+package stale
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStale(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"fresh.md":     "---\nreviewed: 2024-05-01\nowner: alice\n---\n",
+		"boundary.md":  "---\nreviewed: 2024-03-02\nowner: alice\n---\n",
+		"old.md":       "---\nreviewed: 2024-01-01\nowner: alice\n---\n",
+		"older.md":     "---\nreviewed: 2023-06-01\nowner: alice\n---\n",
+		"legacy.md":    "---\nlastReviewed: 2023-01-01\nowner: bob\n---\n",
+		"both.md":      "---\nreviewed: 2024-05-01\nlastReviewed: 2020-01-01\nowner: bob\n---\n",
+		"never.md":     "---\nowner: bob\n---\n",
+		"invalid.md":   "---\nreviewed: yesterday\n---\n",
+		"timestamp.md": "---\nreviewed: 2024-01-31T12:00:00Z\nmeta: {owner: carol}\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := Now(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC))
+	age := 90 * 24 * time.Hour
+
+	f := func(name, reviewed string, days int) File {
+		return File{Name: filepath.Join(dir, name), Reviewed: reviewed, Days: days}
+	}
+
+	for _, tc := range []struct {
+		name string
+		age  time.Duration
+		opts []Option
+		want []Group
+	}{
+		{
+			name: "default",
+			age:  age,
+			opts: []Option{now},
+			want: []Group{
+				{Owner: "", Files: []File{f("invalid.md", "", 0), f("timestamp.md", "2024-01-31", 120)}},
+				{Owner: "alice", Files: []File{f("older.md", "2023-06-01", 365), f("old.md", "2024-01-01", 151)}},
+				{Owner: "bob", Files: []File{f("never.md", "", 0), f("legacy.md", "2023-01-01", 516)}},
+			},
+		},
+		{
+			name: "field",
+			age:  age,
+			opts: []Option{now, Field("lastReviewed"), Owner("meta.owner"), Glob("**/{both,legacy,timestamp}.md")},
+			want: []Group{
+				{Owner: "", Files: []File{f("both.md", "2020-01-01", 1612), f("legacy.md", "2023-01-01", 516)}},
+				{Owner: "carol", Files: []File{f("timestamp.md", "", 0)}},
+			},
+		},
+		{
+			name: "owner",
+			age:  age,
+			opts: []Option{now, Owner("meta.owner"), Glob("**/timestamp.md")},
+			want: []Group{
+				{Owner: "carol", Files: []File{f("timestamp.md", "2024-01-31", 120)}},
+			},
+		},
+		{
+			name: "expr",
+			age:  age,
+			opts: []Option{now, Expr(`owner == "alice"`)},
+			want: []Group{
+				{Owner: "alice", Files: []File{f("older.md", "2023-06-01", 365), f("old.md", "2024-01-01", 151)}},
+			},
+		},
+		{
+			name: "age",
+			age:  400 * 24 * time.Hour,
+			opts: []Option{now, Glob("**/{old,older,legacy}.md")},
+			want: []Group{
+				{Owner: "bob", Files: []File{f("legacy.md", "2023-01-01", 516)}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Stale(dir, tc.age, tc.opts...)
+			if err != nil {
+				t.Fatalf("Stale() = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Stale() =\n%+v\nwant:\n%+v", got, tc.want)
+			}
+		})
+	}
+}
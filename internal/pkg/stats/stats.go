@@ -0,0 +1,139 @@
+// Package stats provides functionality to aggregate document metadata.
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	"github.com/slewiskelly/ock/internal/pkg/get"
+)
+
+// Summary represents the aggregated metadata of a set of files.
+type Summary struct {
+	Files  int      `json:"files"`  // Number of files.
+	Fields []*Field `json:"fields"` // Aggregated fields, ordered by path.
+}
+
+// Field represents an individual field, aggregated across files.
+type Field struct {
+	Path    string  `json:"path"`             // Path of the field.
+	Present int     `json:"present"`          // Number of files containing the field.
+	Missing int     `json:"missing"`          // Number of files not containing the field.
+	Values  []Value `json:"values,omitempty"` // Occurrences of each value, or list element.
+	Min     string  `json:"min,omitempty"`    // Earliest value, if all values are dates.
+	Max     string  `json:"max,omitempty"`    // Latest value, if all values are dates.
+
+	dates    bool // Whether all values are dates.
+	min, max time.Time
+}
+
+// Value represents the occurrences of an individual value.
+type Value struct {
+	Value string `json:"value"` // Value, or list element.
+	Count int    `json:"count"` // Number of occurrences.
+}
+
+// Stats aggregates the metadata of (markdown) files rooted at the given path.
+func Stats(path string, opts ...Option) (*Summary, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	r, err := get.Get(path, get.Expr(o.expr), get.Glob(o.glob))
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]*Field)
+	counts := make(map[string]map[string]int)
+
+	field := func(p string) *Field {
+		if _, ok := byPath[p]; !ok {
+			byPath[p] = &Field{Path: p, dates: true}
+			counts[p] = make(map[string]int)
+		}
+		return byPath[p]
+	}
+
+	if o.schema != nil {
+		if err := o.schema.Err(); err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+
+		fields.Walk(o.schema.LookupPath(cue.ParsePath("#Metadata")), func(x fields.Field) {
+			if !x.Struct() {
+				field(x.String())
+			}
+		}, cue.Optional(true))
+	}
+
+	for _, f := range r {
+		fields.Walk(f.Metadata, func(y fields.Field) {
+			if y.Struct() {
+				return
+			}
+
+			p := y.String()
+
+			x := field(p)
+			x.Present++
+
+			for _, e := range fields.Elems(y.Value) {
+				if e.Kind() != cue.StringKind {
+					x.dates = false
+				}
+
+				s := fields.Format(e)
+				if s == "" && e.Kind() != cue.StringKind {
+					continue
+				}
+
+				counts[p][s]++
+
+				if t, ok := fields.Date(s); ok && x.dates {
+					if x.min.IsZero() || t.Before(x.min) {
+						x.min, x.Min = t, s
+					}
+					if x.max.IsZero() || t.After(x.max) {
+						x.max, x.Max = t, s
+					}
+				} else {
+					x.dates = false
+				}
+			}
+		})
+	}
+
+	s := &Summary{Files: len(r)}
+
+	for p, f := range byPath {
+		f.Missing = s.Files - f.Present
+
+		if !f.dates {
+			f.Min, f.Max = "", ""
+		}
+
+		for v, n := range counts[p] {
+			f.Values = append(f.Values, Value{Value: v, Count: n})
+		}
+
+		slices.SortFunc(f.Values, func(a, b Value) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Value, b.Value))
+		})
+
+		s.Fields = append(s.Fields, f)
+	}
+
+	slices.SortFunc(s.Fields, func(a, b *Field) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return s, nil
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestStats(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"a.md": "---\nstatus: draft\nreviewed: 2024-03-01\ntags: [api, cli]\nmeta: {owner: alice}\n---\n",
+		"b.md": "---\nstatus: published\nreviewed: 2023-12-31\ntags: [api]\nmeta: {owner: bob}\n---\n",
+		"c.md": "---\nstatus: draft\nreviewed: 2024-01-15T10:00:00Z\nweight: 2\n---\n",
+		"d.md": "---\nstatus: draft\nreviewed: soon\ntags: []\n---\n",
+		"e.md": "No frontmatter.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		opts  []Option
+		files int
+		want  []string // Path, present, missing, min, max, and values of each field.
+	}{
+		{
+			name:  "all",
+			files: 4,
+			want: []string{
+				"meta.owner 2 2   [{alice 1} {bob 1}]",
+				"reviewed 4 0   [{2023-12-31 1} {2024-01-15T10:00:00Z 1} {2024-03-01 1} {soon 1}]",
+				"status 4 0   [{draft 3} {published 1}]",
+				"tags 3 1   [{api 2} {cli 1}]",
+				"weight 1 3   [{2 1}]",
+			},
+		},
+		{
+			name:  "dates",
+			opts:  []Option{Glob("**/[abc].md")},
+			files: 3,
+			want: []string{
+				"meta.owner 2 1   [{alice 1} {bob 1}]",
+				"reviewed 3 0 2023-12-31 2024-03-01 [{2023-12-31 1} {2024-01-15T10:00:00Z 1} {2024-03-01 1}]",
+				"status 3 0   [{draft 2} {published 1}]",
+				"tags 2 1   [{api 2} {cli 1}]",
+				"weight 1 2   [{2 1}]",
+			},
+		},
+		{
+			name:  "expr",
+			opts:  []Option{Expr(`status == "published"`)},
+			files: 1,
+			want: []string{
+				"meta.owner 1 0   [{bob 1}]",
+				"reviewed 1 0 2023-12-31 2023-12-31 [{2023-12-31 1}]",
+				"status 1 0   [{published 1}]",
+				"tags 1 0   [{api 1}]",
+			},
+		},
+		{
+			name:  "schema",
+			opts:  []Option{Expr(`status == "published"`), Schema(cuecontext.New().CompileString(`#Metadata: {status: string, summary?: string, meta: {team?: string}}`))},
+			files: 1,
+			want: []string{
+				"meta.owner 1 0   [{bob 1}]",
+				"meta.team 0 1   []",
+				"reviewed 1 0 2023-12-31 2023-12-31 [{2023-12-31 1}]",
+				"status 1 0   [{published 1}]",
+				"summary 0 1   []",
+				"tags 1 0   [{api 1}]",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Stats(dir, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+
+			for _, f := range s.Fields {
+				got = append(got, fmt.Sprintf("%s %d %d %s %s %v", f.Path, f.Present, f.Missing, f.Min, f.Max, f.Values))
+			}
+
+			if s.Files != tc.files || !slices.Equal(got, tc.want) {
+				t.Errorf("Stats() = %d, %q, want %d, %q", s.Files, got, tc.files, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package vet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+// baseline represents previously recorded findings, as the fields with findings
+// of each file.
+//
+// Findings are recorded by field, rather than by line, such that unrelated
+// changes to a file do not invalidate its baseline.
+type baseline map[string][]string
+
+// WriteBaseline records the findings of the given report to a baseline file, at
+// the given path.
+//
+// Subsequent validation, using the Baseline option, reports only findings not
+// recorded by the baseline.
+func WriteBaseline(path string, r report.Report) error {
+	b := make(baseline)
+
+	for _, f := range r {
+		for _, x := range f.Findings {
+			n := key(f.Name)
+
+			if !slices.Contains(b[n], x.Field) {
+				b[n] = append(b[n], x.Field)
+			}
+		}
+	}
+
+	for _, f := range b {
+		slices.Sort(f)
+	}
+
+	d, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(d, '\n'), 0o644)
+}
+
+func readBaseline(path string) (baseline, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b baseline
+
+	if err := json.Unmarshal(d, &b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// filter returns the findings of the given file which are not recorded by the
+// baseline.
+func (b baseline) filter(name string, f []report.Finding) []report.Finding {
+	fields := b[key(name)]
+
+	return slices.DeleteFunc(f, func(x report.Finding) bool {
+		return slices.Contains(fields, x.Field)
+	})
+}
+
+// key returns the key of a file, which is independent of the operating system.
+func key(name string) string {
+	return filepath.ToSlash(filepath.Clean(name))
+}
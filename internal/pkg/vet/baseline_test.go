@@ -0,0 +1,63 @@
+package vet
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	s := compile(t, `#Metadata: {title: string, status: "draft" | "published"}`)
+
+	write := func(name, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.md", "---\nstatus: unknown\n---\n")
+	write("b.md", "---\ntitle: B\nstatus: draft\n---\n")
+
+	r, err := Vet(dir, s)
+	if err != nil {
+		t.Fatalf("Vet() = %v", err)
+	}
+
+	b := filepath.Join(t.TempDir(), "baseline.json")
+
+	if err := WriteBaseline(b, r); err != nil {
+		t.Fatalf("WriteBaseline() = %v", err)
+	}
+
+	// Findings recorded by the baseline are no longer reported, regardless of
+	// changes elsewhere in the file, whereas new findings are.
+	write("a.md", "---\n# Moved.\n\nstatus: unknown\n---\n")
+	write("b.md", "---\ntitle: B\nstatus: unknown\n---\n")
+
+	r, err = Vet(dir, s, Baseline(b))
+	if err != nil {
+		t.Fatalf("Vet(Baseline()) = %v", err)
+	}
+
+	var got []string
+
+	for _, f := range r {
+		for _, x := range f.Findings {
+			got = append(got, filepath.Base(f.Name)+" "+x.Field)
+		}
+	}
+
+	if want := []string{"b.md status"}; !slices.Equal(got, want) {
+		t.Errorf("Vet(Baseline()) = %q, want %q", got, want)
+	}
+}
+
+func TestBaselineMissing(t *testing.T) {
+	if _, err := Vet(t.TempDir(), compile(t, `#Metadata: {}`), Baseline(filepath.Join(t.TempDir(), "nope.json"))); err == nil {
+		t.Errorf("Vet(Baseline(nonexistent)) = nil, want error")
+	}
+}
@@ -0,0 +1,32 @@
+package vet
+
+import (
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/body"
+)
+
+// BodyField is the hidden field of a schema which is provided facts derived
+// from the body of the document being validated, allowing #Metadata to relate
+// metadata to content, for example:
+//
+//	_body: _
+//
+//	#Metadata: {
+//		title: _body.h1
+//	}
+const BodyField = "_body"
+
+// withBody returns the schema with facts, derived from the given body, provided
+// to its _body field. The schema is returned as is if the field is not declared.
+func withBody(schema cue.Value, b []byte) cue.Value {
+	// Hidden fields are scoped to their package, so the field's selector is
+	// used, rather than one constructed from its name.
+	for i, _ := schema.Fields(cue.Hidden(true), cue.Optional(true)); i.Next(); {
+		if i.Selector().String() == BodyField {
+			return schema.FillPath(cue.MakePath(i.Selector()), schema.Context().Encode(body.Parse(b)))
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,78 @@
+package vet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMissing(t *testing.T) {
+	policies := []policy{
+		{"", LvlError},
+		{"docs/drafts/**", 0},
+		{"docs/drafts/important.md", LvlWarn},
+	}
+
+	for _, tc := range []struct {
+		policies []policy
+		path     string
+		want     Lvl
+	}{
+		{nil, "docs/a.md", 0},
+		{policies, "docs/a.md", LvlError},
+		{policies, "docs/drafts/a.md", 0},
+		{policies, "docs/drafts/nested/a.md", 0},
+		{policies, "docs/drafts/important.md", LvlWarn},
+	} {
+		if got := missing(tc.policies, tc.path); got != tc.want {
+			t.Errorf("missing(%v, %q) = %v, want %v", tc.policies, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestVetMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("Body.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := compile(t, `#Metadata: {}`)
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want string // Severity of the file's finding, if reported.
+	}{
+		{name: "default"},
+		{name: "ignore", opts: []Option{Missing("", 0)}},
+		{name: "error", opts: []Option{Missing("", LvlError)}, want: "error"},
+		{name: "below level", opts: []Option{Missing("", LvlInfo)}},
+		{name: "at level", opts: []Option{Missing("", LvlInfo), Level(LvlInfo)}, want: "info"},
+		{name: "unmatched", opts: []Option{Missing("**/drafts/*.md", LvlError)}},
+		{name: "precedence", opts: []Option{Missing("", LvlError), Missing("**/a.md", LvlWarn)}, want: "warning"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Vet(dir, s, tc.opts...)
+			if err != nil {
+				t.Fatalf("Vet() = %v", err)
+			}
+
+			var got string
+
+			if len(r) > 0 && len(r[0].Findings) > 0 {
+				got = r[0].Findings[0].Severity
+			}
+
+			if got != tc.want {
+				t.Errorf("Vet() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVetMissingInvalid(t *testing.T) {
+	if _, err := Vet(t.TempDir(), compile(t, `#Metadata: {}`), Missing("[", LvlError)); err == nil {
+		t.Errorf("Vet(Missing(invalid)) = nil, want error")
+	}
+}
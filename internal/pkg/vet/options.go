@@ -1,10 +1,39 @@
 package vet
 
+import (
+	"github.com/slewiskelly/ock/internal/pkg/owners"
+)
+
 // Option is an option to Vet.
 type Option interface {
 	apply(*options)
 }
 
+// Baseline specifies the location of a baseline file, see WriteBaseline, whose
+// recorded findings are not reported.
+func Baseline(path string) Option {
+	return option(func(o *options) {
+		o.baseline = path
+	})
+}
+
+// Body specifies the body of the document whose metadata is validated, from
+// which the facts provided to the schema's _body field are derived.
+func Body(b []byte) Option {
+	return option(func(o *options) {
+		o.body = b
+	})
+}
+
+// Dir specifies the directory of the document whose metadata is validated,
+// against which the paths of fields with the @path or @ref attributes are
+// resolved. Such fields are only checked if a directory is specified.
+func Dir(dir string) Option {
+	return option(func(o *options) {
+		o.dir = dir
+	})
+}
+
 // Glob specifies a pattern to filter files that are attempted to be validated.
 func Glob(pattern string) Option {
 	return option(func(o *options) {
@@ -19,9 +48,73 @@ func Level(l Lvl) Option {
 	})
 }
 
+// Missing specifies the level of the finding reported for files, matching the
+// given pattern, which have no metadata. An empty pattern matches all files.
+//
+// Files without metadata are otherwise ignored, as are those whose level is 0.
+// If multiple patterns match a file, the last takes precedence.
+func Missing(pattern string, l Lvl) Option {
+	return option(func(o *options) {
+		o.missing = append(o.missing, policy{pattern, l})
+	})
+}
+
+// Owners specifies the location of a source of truth of owners, see owners.Read,
+// against which fields with the @owner attribute are checked. Such fields are
+// only checked if a source is specified.
+func Owners(path string) Option {
+	return option(func(o *options) {
+		o.owners = path
+	})
+}
+
+// Root specifies the root directory of the site, against which absolute paths,
+// such as "/images/diagram.png", of fields with the @path or @ref attributes
+// are resolved. Such paths are ignored if no root is specified.
+//
+// Vet defaults to the path being validated, or its directory if a file.
+func Root(dir string) Option {
+	return option(func(o *options) {
+		o.root = dir
+	})
+}
+
+// StaleSuppressions specifies whether suppressions of fields without findings
+// are reported as errors.
+func StaleSuppressions(b bool) Option {
+	return option(func(o *options) {
+		o.stale = b
+	})
+}
+
+// Suppress specifies additional suppressions, such as those declared by
+// comments, see Suppressions.
+func Suppress(s ...Suppression) Option {
+	return option(func(o *options) {
+		o.suppressions = append(o.suppressions, s...)
+	})
+}
+
 type options struct {
-	glob string
-	lvl  Lvl
+	baseline     string
+	body         []byte
+	dir          string
+	glob         string
+	lvl          Lvl
+	missing      []policy
+	name         string
+	owners       string
+	roster       *owners.Owners
+	root         string
+	stale        bool
+	suppressions []Suppression
+}
+
+// policy represents the level of findings for files, matching a pattern, which
+// have no metadata.
+type policy struct {
+	pattern string
+	lvl     Lvl
 }
 
 type option func(*options)
@@ -0,0 +1,117 @@
+package vet
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/fields"
+	"github.com/slewiskelly/ock/internal/pkg/owners"
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+// HasOwners reports whether any field of the #Metadata definition of the given
+// schema has the @owner attribute, and so requires owners, see Owners.
+func HasOwners(schema cue.Value) bool {
+	var ok bool
+
+	fields.Walk(schema.LookupPath(cue.ParsePath("#Metadata")), func(f fields.Field) {
+		if a := f.Value.Attribute("owner"); !f.Struct() && a.Err() == nil {
+			ok = true
+		}
+	}, cue.Optional(true))
+
+	return ok
+}
+
+// owned checks the fields of v which hold owners, that is, those with the
+// @owner attribute, against the given owners, for the document at path p.
+//
+// The @owner attribute requires that each owner exists, and, if specified by
+// @owner(codeowners), that at least one is an owner of the document according
+// to CODEOWNERS.
+//
+// Fields may be strings, or lists of strings. Fields which fail validation are
+// ignored. Findings are of the level, and message, given by the field's
+// severity attribute, if any.
+func owned(v cue.Value, o *owners.Owners, p string) []report.Finding {
+	i, err := v.Fields()
+	if err != nil {
+		return nil
+	}
+
+	var f []report.Finding
+
+	for i.Next() {
+		x := i.Value()
+
+		if Nested(x) {
+			f = append(f, owned(x, o, p)...)
+			continue
+		}
+
+		a := x.Attribute("owner")
+		if a.Err() != nil || x.Validate(cue.Concrete(true)) != nil {
+			continue
+		}
+
+		m, l := severity(x)
+		if m == "" {
+			m = "invalid owner"
+		}
+
+		names := strs(x)
+
+		for _, n := range names {
+			if !o.Exists(n) {
+				f = append(f, finding(x.Path().String(), fmt.Sprintf("%s: %q does not exist", m, n), l))
+			}
+		}
+
+		if a.Contents() != "codeowners" || len(names) < 1 {
+			continue
+		}
+
+		c, ok := o.For(p)
+
+		switch {
+		case !ok:
+			f = append(f, finding(x.Path().String(), fmt.Sprintf("%s: owners of the document are unknown, as owners were not read from CODEOWNERS", m), l))
+		case len(c) < 1:
+			f = append(f, finding(x.Path().String(), fmt.Sprintf("%s: the document has no owners, according to CODEOWNERS", m), l))
+		case !anyOwns(c, names):
+			f = append(f, finding(x.Path().String(), fmt.Sprintf("%s: none of %s own the document, according to CODEOWNERS (%s)", m, strings.Join(names, ", "), strings.Join(c, ", ")), l))
+		}
+	}
+
+	return f
+}
+
+// strs returns v, or its elements if a list, which are strings.
+func strs(v cue.Value) []string {
+	var s []string
+
+	if x, err := v.String(); err == nil {
+		return []string{x}
+	}
+
+	for i, _ := v.List(); i.Next(); {
+		if x, err := i.Value().String(); err == nil {
+			s = append(s, x)
+		}
+	}
+
+	return s
+}
+
+// anyOwns reports whether any of the given names is one of the given owners.
+func anyOwns(c, names []string) bool {
+	for _, n := range names {
+		if owners.Owns(c, n) {
+			return true
+		}
+	}
+
+	return false
+}
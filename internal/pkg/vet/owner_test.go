@@ -0,0 +1,96 @@
+package vet
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/owners"
+)
+
+func TestHasOwners(t *testing.T) {
+	for _, tc := range []struct {
+		schema string
+		want   bool
+	}{
+		{`#Metadata: {owner: string}`, false},
+		{`#Metadata: {owner: string @owner()}`, true},
+		{`#Metadata: {owner?: string @owner(codeowners)}`, true},
+		{`#Metadata: {meta: {owner: string @owner()}}`, true},
+		{`#Metadata: {owner: #Owner}, #Owner: string @owner()`, false},
+	} {
+		if got := HasOwners(compile(t, tc.schema)); got != tc.want {
+			t.Errorf("HasOwners(%s) = %v, want %v", tc.schema, got, tc.want)
+		}
+	}
+}
+
+func TestOwned(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		t.Helper()
+
+		p := filepath.Join(dir, name)
+
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return p
+	}
+
+	codeowners, err := owners.Read(write("CODEOWNERS", "*  @everyone\ndocs/*  @docs\ndocs/api/  @api\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roster, err := owners.Read(write("roster.yaml", "- docs\n- api\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := compile(t, `#Metadata: {
+	owner?:     string    @owner()
+	maintainer?: string   @owner(codeowners)
+	reviewers?: [...string] @owner(codeowners) @warning(unknown reviewer)
+}`).LookupPath(cue.ParsePath("#Metadata"))
+
+	for _, tc := range []struct {
+		name     string
+		owners   *owners.Owners
+		path     string
+		metadata string
+		want     []string // Severity, and field, of each finding.
+	}{
+		{name: "exists", owners: roster, path: "docs/a.md", metadata: `{owner: "@Docs"}`},
+		{name: "does not exist", owners: roster, path: "docs/a.md", metadata: `{owner: "nobody"}`, want: []string{"error owner"}},
+		{name: "owns", owners: codeowners, path: "docs/a.md", metadata: `{maintainer: "docs"}`},
+		{name: "does not own", owners: codeowners, path: "docs/a.md", metadata: `{maintainer: "api"}`, want: []string{"error maintainer"}},
+		{name: "wildcard excludes nested", owners: codeowners, path: "docs/guides/a.md", metadata: `{maintainer: "docs"}`, want: []string{"error maintainer"}},
+		{name: "wildcard nested", owners: codeowners, path: "docs/guides/a.md", metadata: `{maintainer: "everyone"}`},
+		{name: "directory", owners: codeowners, path: "docs/api/v1/a.md", metadata: `{maintainer: "api"}`},
+		{name: "any owns", owners: codeowners, path: "docs/api/a.md", metadata: `{reviewers: ["docs", "api"]}`},
+		{name: "none own", owners: codeowners, path: "docs/api/a.md", metadata: `{reviewers: ["docs"]}`, want: []string{"warning reviewers"}},
+		{name: "unknown and not owner", owners: codeowners, path: "docs/a.md", metadata: `{reviewers: ["nobody"]}`, want: []string{"warning reviewers", "warning reviewers"}},
+		{name: "without codeowners", owners: roster, path: "docs/a.md", metadata: `{maintainer: "docs"}`, want: []string{"error maintainer"}},
+		{name: "invalid", owners: roster, path: "docs/a.md", metadata: `{owner: 1}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := compile(t, tc.metadata).Unify(s)
+
+			var got []string
+
+			for _, f := range owned(v, tc.owners, filepath.Join(dir, tc.path)) {
+				got = append(got, f.Severity+" "+f.Field)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("owned() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package vet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+// PathKinds are the kinds of path which may be required by the @path attribute.
+var PathKinds = []string{"exists", "file", "dir"}
+
+// references checks the fields of v which reference files, that is, those with
+// either the @path or @ref attribute, against the filesystem, resolving
+// relative paths against dir, and absolute paths against root, or ignoring them
+// if root is empty.
+//
+// The @path attribute requires that the path exists, or, if specified, that it
+// is a file, or directory, for example @path(dir). The @ref attribute requires
+// that the path is a file with one of the given extensions, for example
+// @ref(md).
+//
+// Fields may be strings, or lists of strings. URLs, and fields which fail
+// validation, are ignored. Findings are of the level, and message, given by the
+// field's severity attribute, if any.
+func references(v cue.Value, dir, root string, lvl Lvl) []report.Finding {
+	i, err := v.Fields()
+	if err != nil {
+		return nil
+	}
+
+	var f []report.Finding
+
+	for i.Next() {
+		x := i.Value()
+
+		if Nested(x) {
+			f = append(f, references(x, dir, root, lvl)...)
+			continue
+		}
+
+		p, r := x.Attribute("path"), x.Attribute("ref")
+		if (p.Err() != nil && r.Err() != nil) || x.Validate(cue.Concrete(true)) != nil {
+			continue
+		}
+
+		m, l := severity(x)
+		if l < lvl {
+			continue
+		}
+
+		for _, y := range paths(x) {
+			s, _ := y.String()
+			s, _, _ = strings.Cut(s, "#")
+
+			base := dir
+
+			if strings.HasPrefix(s, "/") {
+				if root == "" {
+					continue
+				}
+
+				base = root
+			}
+
+			var msg string
+
+			if p.Err() == nil {
+				msg = path(filepath.Join(base, s), p.Contents())
+			} else {
+				msg = ref(filepath.Join(base, s), r.Contents())
+			}
+
+			if msg == "" {
+				continue
+			}
+
+			if m == "" {
+				m = "broken reference"
+			}
+
+			f = append(f, finding(y.Path().String(), fmt.Sprintf("%s: %q %s", m, s, msg), l))
+		}
+	}
+
+	return f
+}
+
+// paths returns v, or its elements if a list, which are paths, that is,
+// strings other than URLs, or fragments of the document itself.
+func paths(v cue.Value) []cue.Value {
+	var p []cue.Value
+
+	add := func(x cue.Value) {
+		if s, err := x.String(); err == nil && !strings.Contains(s, "://") && !strings.HasPrefix(s, "#") && s != "" {
+			p = append(p, x)
+		}
+	}
+
+	if v.IncompleteKind() != cue.ListKind {
+		add(v)
+		return p
+	}
+
+	for i, _ := v.List(); i.Next(); {
+		add(i.Value())
+	}
+
+	return p
+}
+
+// path checks that p exists, and is of the given kind, returning a message
+// describing why not.
+func path(p, kind string) string {
+	fi, err := os.Stat(p)
+
+	switch {
+	case err != nil:
+		return "does not exist"
+	case kind == "file" && !fi.Mode().IsRegular():
+		return "is not a file"
+	case kind == "dir" && !fi.IsDir():
+		return "is not a directory"
+	}
+
+	return ""
+}
+
+// ref checks that p is a file with one of the given (comma separated)
+// extensions, returning a message describing why not.
+func ref(p, exts string) string {
+	if msg := path(p, "file"); msg != "" {
+		return msg
+	}
+
+	var e []string
+
+	for _, x := range strings.Split(exts, ",") {
+		if x = strings.TrimPrefix(strings.TrimSpace(x), "."); x != "" {
+			e = append(e, x)
+		}
+	}
+
+	if len(e) > 0 && !slices.Contains(e, strings.TrimPrefix(filepath.Ext(p), ".")) {
+		return fmt.Sprintf("is not a .%s file", strings.Join(e, ", ."))
+	}
+
+	return ""
+}
@@ -0,0 +1,81 @@
+package vet
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue"
+)
+
+func TestReferences(t *testing.T) {
+	root := t.TempDir()
+
+	for _, d := range []string{"docs/assets", "images"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, f := range []string{"docs/a.md", "docs/b.txt", "images/x.png"} {
+		if err := os.WriteFile(filepath.Join(root, f), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := compile(t, `#Metadata: {
+	exists?:  string      @path()
+	file?:    string      @path(file)
+	dir?:     string      @path(dir)
+	related?: [...string] @ref(md)
+	image?:   string      @path(file) @warning(missing image)
+	meta?: {
+		doc?: string @ref(md, txt)
+	}
+}`).LookupPath(cue.ParsePath("#Metadata"))
+
+	for _, tc := range []struct {
+		name     string
+		metadata string
+		root     string
+		lvl      Lvl
+		want     []string // Severity, field, and message, of each finding.
+	}{
+		{name: "exists", metadata: `{exists: "assets"}`},
+		{name: "does not exist", metadata: `{exists: "c.md"}`, want: []string{`error exists broken reference: "c.md" does not exist`}},
+		{name: "file", metadata: `{file: "a.md"}`},
+		{name: "not a file", metadata: `{file: "assets"}`, want: []string{`error file broken reference: "assets" is not a file`}},
+		{name: "dir", metadata: `{dir: "assets"}`},
+		{name: "not a dir", metadata: `{dir: "a.md"}`, want: []string{`error dir broken reference: "a.md" is not a directory`}},
+		{name: "parent", metadata: `{file: "../images/x.png"}`},
+		{name: "ref", metadata: `{related: ["a.md", "./a.md"]}`},
+		{name: "ref anchor", metadata: `{related: ["a.md#section"]}`},
+		{name: "ref fragment", metadata: `{related: ["#section"]}`},
+		{name: "ref url", metadata: `{related: ["https://example.com/c.md"]}`},
+		{name: "ref extension", metadata: `{related: ["a.md", "b.txt"]}`, want: []string{`error related[1] broken reference: "b.txt" is not a .md file`}},
+		{name: "ref anchor does not exist", metadata: `{related: ["c.md#section"]}`, want: []string{`error related[0] broken reference: "c.md" does not exist`}},
+		{name: "ref extensions", metadata: `{meta: {doc: "b.txt"}}`},
+		{name: "nested", metadata: `{meta: {doc: "x.png"}}`, want: []string{`error meta.doc broken reference: "x.png" does not exist`}},
+		{name: "absolute", metadata: `{file: "/images/x.png"}`, root: root},
+		{name: "absolute does not exist", metadata: `{file: "/images/y.png"}`, root: root, want: []string{`error file broken reference: "/images/y.png" does not exist`}},
+		{name: "absolute without root", metadata: `{file: "/images/y.png"}`},
+		{name: "severity", metadata: `{image: "x.png"}`, want: []string{`warning image missing image: "x.png" does not exist`}},
+		{name: "below level", metadata: `{image: "x.png"}`, lvl: LvlError},
+		{name: "invalid", metadata: `{file: 1}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := compile(t, tc.metadata).Unify(s)
+
+			var got []string
+
+			for _, f := range references(v, filepath.Join(root, "docs"), tc.root, tc.lvl) {
+				got = append(got, f.Severity+" "+f.Field+" "+f.Message)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("references() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
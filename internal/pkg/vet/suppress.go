@@ -0,0 +1,159 @@
+package vet
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+// IgnoreKey is the metadata key which lists fields whose findings are
+// suppressed, for example:
+//
+//	ock-ignore: [reviewed, "tags reason=not applicable"]
+//
+// The key itself is not validated.
+const IgnoreKey = "ock-ignore"
+
+// Suppression represents the suppression of the findings of a field.
+type Suppression struct {
+	Field  string // Path of the field, including any nested fields.
+	Reason string // Reason for the suppression, if given.
+}
+
+var comment = regexp.MustCompile(`(?:^|\s)#\s*ock:ignore\s+(.+)$`)
+
+// Suppressions returns the suppressions declared by comments within the given
+// frontmatter data, of the form:
+//
+//	# ock:ignore <field>... [reason=<reason>]
+func Suppressions(data []byte) []Suppression {
+	var s []Suppression
+
+	for sc := bufio.NewScanner(bytes.NewReader(data)); sc.Scan(); {
+		if m := comment.FindStringSubmatch(sc.Text()); m != nil {
+			s = append(s, parse(m[1])...)
+		}
+	}
+
+	return s
+}
+
+// parse parses the fields, and optional reason, of a suppression.
+func parse(s string) []Suppression {
+	fields, reason, _ := strings.Cut(s, "reason=")
+
+	var x []Suppression
+
+	for _, f := range strings.Fields(fields) {
+		x = append(x, Suppression{Field: f, Reason: strings.TrimSpace(reason)})
+	}
+
+	return x
+}
+
+// check validates the given metadata against the #Metadata definition of the
+// given schema, separating suppressed findings from the remainder.
+func check(metadata, schema cue.Value, o *options) (kept, suppressed []report.Finding) {
+	if o.body != nil {
+		schema = withBody(schema, o.body)
+	}
+
+	schema = schema.LookupPath(cue.ParsePath("#Metadata"))
+
+	s := append(ignored(metadata), o.suppressions...)
+
+	used := make([]bool, len(s))
+
+	// Findings of all levels are considered, such that suppressions of findings
+	// below the minimum level are not considered stale.
+	v := without(metadata, IgnoreKey).Unify(schema)
+
+	all := validate(v, 0)
+
+	if o.dir != "" {
+		all = append(all, references(v, o.dir, o.root, 0)...)
+	}
+
+	if o.roster != nil {
+		all = append(all, owned(v, o.roster, o.name)...)
+	}
+
+	for _, f := range all {
+		i := match(s, f.Field)
+		if i >= 0 {
+			used[i] = true
+			f.Reason = s[i].Reason
+		}
+
+		switch {
+		case Lvl(f.Level) < o.lvl:
+		case i >= 0:
+			suppressed = append(suppressed, f)
+		default:
+			kept = append(kept, f)
+		}
+	}
+
+	if o.stale {
+		for i, x := range s {
+			if !used[i] {
+				kept = append(kept, finding(x.Field, "stale suppression, the field has no findings", LvlError))
+			}
+		}
+	}
+
+	return kept, suppressed
+}
+
+// ignored returns the suppressions listed by the metadata's ignore key.
+func ignored(metadata cue.Value) []Suppression {
+	v := metadata.LookupPath(cue.MakePath(cue.Str(IgnoreKey)))
+
+	if s, err := v.String(); err == nil {
+		return parse(s)
+	}
+
+	var x []Suppression
+
+	for i, _ := v.List(); i.Next(); {
+		if s, err := i.Value().String(); err == nil {
+			x = append(x, parse(s)...)
+		}
+	}
+
+	return x
+}
+
+// match returns the index of the suppression which applies to the given field,
+// or -1 if none do. Suppressions also apply to any nested fields, or elements.
+func match(s []Suppression, field string) int {
+	for i, x := range s {
+		if field == x.Field || strings.HasPrefix(field, x.Field+".") || strings.HasPrefix(field, x.Field+"[") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// without returns v without the given (top-level) field.
+func without(v cue.Value, key string) cue.Value {
+	if !v.LookupPath(cue.MakePath(cue.Str(key))).Exists() {
+		return v
+	}
+
+	w := v.Context().CompileString("{}")
+
+	for i, _ := v.Fields(); i.Next(); {
+		if i.Selector().Unquoted() != key {
+			w = w.FillPath(cue.MakePath(i.Selector()), i.Value())
+		}
+	}
+
+	return w
+}
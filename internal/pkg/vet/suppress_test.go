@@ -0,0 +1,104 @@
+package vet
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSuppressions(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []Suppression
+	}{
+		{in: "title: Alpha\n"},
+		{in: "# ock:ignore owner\n", want: []Suppression{{Field: "owner"}}},
+		{in: "#ock:ignore owner tags reason=not applicable\n", want: []Suppression{{"owner", "not applicable"}, {"tags", "not applicable"}}},
+		{in: "title: Alpha # ock:ignore title\n", want: []Suppression{{Field: "title"}}},
+		{in: "title: 'a#ock:ignore title'\n"},
+	} {
+		if got := Suppressions([]byte(tc.in)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Suppressions(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s := []Suppression{{Field: "meta"}, {Field: "tags"}}
+
+	for _, tc := range []struct {
+		field string
+		want  int
+	}{
+		{"meta", 0},
+		{"meta.team", 0},
+		{"metadata", -1},
+		{"tags[0]", 1},
+		{"title", -1},
+	} {
+		if got := match(s, tc.field); got != tc.want {
+			t.Errorf("match(%q) = %d, want %d", tc.field, got, tc.want)
+		}
+	}
+}
+
+func TestCheckSuppressed(t *testing.T) {
+	s := compile(t, schema)
+
+	for _, tc := range []struct {
+		name       string
+		metadata   string
+		opts       []Option
+		kept       []string
+		suppressed []string
+	}{
+		{
+			name:     "none",
+			metadata: `{title: "A", status: "draft", tags: ["a"], summary: "", meta: team: "docs"}`,
+		},
+		{
+			name:       "ignore key",
+			metadata:   `{"ock-ignore": ["title", "meta"], status: "draft", tags: ["a"], summary: ""}`,
+			suppressed: []string{"title", "meta.team"},
+		},
+		{
+			name:       "ignore key string",
+			metadata:   `{"ock-ignore": "title reason=later", status: "draft", tags: ["a"], summary: "", meta: team: "docs"}`,
+			suppressed: []string{"title"},
+		},
+		{
+			name:       "option",
+			metadata:   `{status: "draft", tags: ["a"], summary: "", meta: team: "docs"}`,
+			opts:       []Option{Suppress(Suppression{Field: "title"})},
+			suppressed: []string{"title"},
+		},
+		{
+			name:     "below level",
+			metadata: `{"ock-ignore": ["owner"], title: "A", status: "draft", tags: ["a"], summary: "", meta: team: "docs"}`,
+		},
+		{
+			name:     "stale",
+			metadata: `{"ock-ignore": ["title", "owner"], title: "A", status: "draft", tags: ["a"], summary: "", meta: team: "docs"}`,
+			opts:     []Option{StaleSuppressions(true)},
+			kept:     []string{"title"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &options{lvl: LvlWarn}
+
+			for _, opt := range tc.opts {
+				opt.apply(o)
+			}
+
+			k, x := check(compile(t, tc.metadata), s, o)
+
+			if got := names(k); !slices.Equal(got, tc.kept) {
+				t.Errorf("check() kept = %q, want %q", got, tc.kept)
+			}
+
+			if got := names(x); !slices.Equal(got, tc.suppressed) {
+				t.Errorf("check() suppressed = %q, want %q", got, tc.suppressed)
+			}
+		})
+	}
+}
@@ -4,29 +4,93 @@ package vet
 import (
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/errors"
 	"github.com/bmatcuk/doublestar/v4"
 
+	"github.com/slewiskelly/ock/internal/pkg/frontmatter"
 	"github.com/slewiskelly/ock/internal/pkg/get"
+	"github.com/slewiskelly/ock/internal/pkg/owners"
 	"github.com/slewiskelly/ock/internal/pkg/report"
 )
 
-// Lvl represents a validation error level.
+// Lvl represents a validation error level, with higher levels being more
+// severe.
+//
+// Fields may specify the level of their validation errors using the @error,
+// @warning, @notice or @info attributes, or any (positive) level using the
+// @severity attribute, for example @severity(6, message).
 type Lvl int
 
 const (
-	LvlWarn  Lvl = 4
-	LvlError Lvl = 8
+	LvlInfo   Lvl = 1
+	LvlNotice Lvl = 2
+	LvlWarn   Lvl = 4
+	LvlError  Lvl = 8
 )
 
+// ParseLvl parses a level from either its name (info, notice, warn[ing], or
+// err[or]), or its number.
+func ParseLvl(s string) (Lvl, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return LvlInfo, nil
+	case "notice":
+		return LvlNotice, nil
+	case "warn", "warning":
+		return LvlWarn, nil
+	case "err", "error":
+		return LvlError, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid level %q", s)
+	}
+
+	return Lvl(n), nil
+}
+
+// String returns the name of the level, or its number if it has no name.
+func (l Lvl) String() string {
+	switch l {
+	case LvlInfo:
+		return "info"
+	case LvlNotice:
+		return "notice"
+	case LvlWarn:
+		return "warning"
+	case LvlError:
+		return "error"
+	default:
+		return strconv.Itoa(int(l))
+	}
+}
+
 // Vet validates all files rooted at the given path, against the given schema.
 //
 // The returned report contains all files which failed validation, along with
-// their corresponding error(s).
+// their corresponding findings. Findings at, or above, LvlError are also
+// reported as errors, and those at, or above, LvlWarn as warnings.
+//
+// Findings of fields suppressed by a file, either by its ock-ignore key, or by
+// "# ock:ignore" comments, are reported separately, and do not cause the file to
+// fail validation. Findings recorded by a baseline, see the Baseline option, are
+// omitted altogether.
+//
+// Files without metadata are ignored, unless otherwise specified by the Missing
+// option.
+//
+// Facts derived from each file's body are provided to the schema's hidden _body
+// field, if declared, see body.Facts. Paths of fields with the @path or @ref
+// attributes are resolved relative to each file, or to the root, if absolute, see
+// the Root option. Fields with the @owner attribute are checked against owners,
+// if specified by the Owners option.
 func Vet(path string, schema cue.Value, opts ...Option) (report.Report, error) {
 	o := &options{
 		lvl: LvlWarn,
@@ -40,11 +104,41 @@ func Vet(path string, schema cue.Value, opts ...Option) (report.Report, error) {
 		return nil, errors.New("invalid globbing pattern")
 	}
 
+	for _, x := range o.missing {
+		if ok := doublestar.ValidatePathPattern(x.pattern); !ok {
+			return nil, fmt.Errorf("invalid globbing pattern %q", x.pattern)
+		}
+	}
+
 	if err := schema.Err(); err != nil {
 		return nil, fmt.Errorf("invalid schema: %w", err)
 	}
 
-	schema = schema.LookupPath(cue.ParsePath("#Metadata"))
+	var b baseline
+
+	if o.baseline != "" {
+		var err error
+
+		if b, err = readBaseline(o.baseline); err != nil {
+			return nil, fmt.Errorf("invalid baseline: %w", err)
+		}
+	}
+
+	if o.owners != "" {
+		var err error
+
+		if o.roster, err = owners.Read(o.owners); err != nil {
+			return nil, fmt.Errorf("invalid owners: %w", err)
+		}
+	}
+
+	if o.root == "" {
+		o.root = path
+
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			o.root = filepath.Dir(path)
+		}
+	}
 
 	var r report.Report
 
@@ -65,18 +159,38 @@ func Vet(path string, schema cue.Value, opts ...Option) (report.Report, error) {
 
 		v, err := get.Get(p)
 		if err != nil {
-			r = append(r, &report.File{Name: p, Errors: []report.Error{{Message: err.Error()}}})
+			r = append(r, failed(p, err))
 			return nil
 		}
 
-		// TODO(slewiskelly): Signals a lack of metadata, should be considered a
-		// failure?
 		if len(v) < 1 {
+			if l := missing(o.missing, p); l > 0 && l >= o.lvl {
+				if f := b.filter(p, []report.Finding{finding("", "missing frontmatter", l)}); len(f) > 0 {
+					errs, wrns := split(f)
+					r = append(r, &report.File{Name: p, Errors: errs, Warnings: wrns, Findings: f})
+				}
+			}
+
+			return nil
+		}
+
+		fm, err := frontmatter.Read(p)
+		if err != nil {
+			r = append(r, failed(p, err))
 			return nil
 		}
 
-		if errs, wrns := validate(v[0].Metadata.Unify(schema), o.lvl); len(errs) > 0 || len(wrns) > 0 {
-			r = append(r, &report.File{Name: p, Start: v[0].Start, End: v[0].End, Errors: errs, Warnings: wrns})
+		fo := *o
+		fo.body = fm.Body()
+		fo.dir = filepath.Dir(p)
+		fo.name = p
+		fo.suppressions = append(Suppressions(fm.Data()), o.suppressions...)
+
+		f, s := check(v[0].Metadata, schema, &fo)
+
+		if f = b.filter(p, f); len(f) > 0 || len(s) > 0 {
+			errs, wrns := split(f)
+			r = append(r, &report.File{Name: p, Start: v[0].Start, End: v[0].End, Errors: errs, Warnings: wrns, Findings: f, Suppressed: s})
 		}
 
 		return nil
@@ -85,50 +199,145 @@ func Vet(path string, schema cue.Value, opts ...Option) (report.Report, error) {
 	return r, err
 }
 
-func validate(v cue.Value, lvl Lvl) (errs, wrns []report.Error) {
+// missing returns the level of the finding for a file without metadata, according
+// to the last matching policy.
+func missing(policies []policy, p string) Lvl {
+	var l Lvl
+
+	for _, x := range policies {
+		if x.pattern == "" || doublestar.PathMatchUnvalidated(x.pattern, p) {
+			l = x.lvl
+		}
+	}
+
+	return l
+}
+
+// Validate validates the given metadata against the #Metadata definition of the
+// given schema, returning its errors and warnings.
+func Validate(metadata, schema cue.Value, opts ...Option) (errs, wrns []report.Error) {
+	return split(Findings(metadata, schema, opts...))
+}
+
+// Findings validates the given metadata against the #Metadata definition of the
+// given schema, returning its findings of all severities.
+//
+// Findings of fields suppressed by the metadata's ock-ignore key, or by the
+// Suppress option, are omitted.
+func Findings(metadata, schema cue.Value, opts ...Option) []report.Finding {
+	o := &options{
+		lvl: LvlWarn,
+	}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	if err := schema.Err(); err != nil {
+		return []report.Finding{finding("", fmt.Sprintf("invalid schema: %v", err), LvlError)}
+	}
+
+	f, _ := check(metadata, schema, o)
+
+	return f
+}
+
+func validate(v cue.Value, lvl Lvl) []report.Finding {
 	i, err := v.Fields()
 	if err != nil {
-		return []report.Error{{Message: fmt.Sprintf("Failed to validate: %v", err)}}, nil // TODO(slewiskelly): Reconsider.
+		return []report.Finding{finding("", fmt.Sprintf("Failed to validate: %v", err), LvlError)} // TODO(slewiskelly): Reconsider.
 	}
 
+	var f []report.Finding
+
 	for i.Next() {
 		x := i.Value()
 
 		// Recursively check fields if there is a nested structure.
-		if _, err := x.Fields(); err == nil {
-			e, w := validate(x, lvl)
-
-			errs = append(errs, e...)
-			wrns = append(wrns, w...)
-
+		if Nested(x) {
+			f = append(f, validate(x, lvl)...)
 			continue
 		}
 
 		if err := x.Validate(cue.Concrete(true)); err != nil {
-			if a := x.Attribute("error"); a.NumArgs() > 0 {
-				if lvl <= LvlError {
-					errs = append(errs, report.Error{
-						Field:   x.Path().String(),
-						Message: a.Contents(),
-					})
-				}
-				continue
+			msg, l := severity(x)
+			if msg == "" {
+				msg = errDetails(err).Error()
 			}
 
-			if a := x.Attribute("warning"); a.NumArgs() > 0 {
-				if lvl <= LvlWarn {
-					wrns = append(wrns, report.Error{
-						Field:   x.Path().String(),
-						Message: a.Contents(),
-					})
-				}
-				continue
+			if l >= lvl {
+				f = append(f, finding(x.Path().String(), msg, l))
 			}
+		}
+	}
+
+	return f
+}
+
+// Nested reports whether v is a nested structure, with the given options
+// determining which fields are considered.
+//
+// Erroneous values, such as an incomplete list, also yield (no) fields, whereas
+// structs containing erroneous fields are not of the struct kind.
+func Nested(v cue.Value, opts ...cue.Option) bool {
+	i, err := v.Fields(opts...)
+	return err == nil && (v.IncompleteKind() == cue.StructKind || i.Next())
+}
+
+// severity returns the message and level of a field's validation error,
+// according to its attributes.
+//
+// Fields without attributes are considered errors, and use the message of the
+// validation error itself.
+func severity(v cue.Value) (string, Lvl) {
+	for _, x := range []struct {
+		name string
+		lvl  Lvl
+	}{
+		{"error", LvlError},
+		{"warning", LvlWarn},
+		{"notice", LvlNotice},
+		{"info", LvlInfo},
+	} {
+		if a := v.Attribute(x.name); a.NumArgs() > 0 {
+			return a.Contents(), x.lvl
+		}
+	}
+
+	if a := v.Attribute("severity"); a.NumArgs() > 0 {
+		s, msg, _ := strings.Cut(a.Contents(), ",")
+
+		if l, err := ParseLvl(strings.TrimSpace(s)); err == nil {
+			return strings.TrimSpace(msg), l
+		}
+	}
+
+	return "", LvlError
+}
+
+func finding(field, msg string, l Lvl) report.Finding {
+	return report.Finding{Field: field, Message: msg, Severity: l.String(), Level: int(l)}
+}
+
+// failed returns the report of a file whose metadata could not be read, such
+// as when its frontmatter is not closed, or is invalid YAML.
+func failed(p string, err error) *report.File {
+	f := []report.Finding{finding("", err.Error(), LvlError)}
+	errs, wrns := split(f)
+
+	return &report.File{Name: p, Errors: errs, Warnings: wrns, Findings: f}
+}
+
+// split returns the findings which are errors, and warnings.
+func split(f []report.Finding) (errs, wrns []report.Error) {
+	for _, x := range f {
+		e := report.Error{Field: x.Field, Message: x.Message}
 
-			errs = append(errs, report.Error{
-				Field:   x.Path().String(),
-				Message: errDetails(err).Error(),
-			})
+		switch {
+		case x.Level >= int(LvlError):
+			errs = append(errs, e)
+		case x.Level >= int(LvlWarn):
+			wrns = append(wrns, e)
 		}
 	}
 
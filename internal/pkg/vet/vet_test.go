@@ -0,0 +1,165 @@
+package vet
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
+	"github.com/slewiskelly/ock/internal/pkg/report"
+)
+
+const schema = `
+#Metadata: {
+	title:    string
+	status:   "draft" | "published"                    @warning(unknown status)
+	owner:    string                                   @notice(missing owner)
+	reviewed: string                                   @info(never reviewed)
+	tags:     [_, ...string]                           @severity(6, tags are required)
+	summary:  string                                   @severity(bogus, ignored)
+	meta: {
+		team: string @error(missing team)
+	}
+}
+`
+
+func compile(t *testing.T, s string) cue.Value {
+	t.Helper()
+
+	v := cuecontext.New().CompileString(s)
+	if err := v.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
+
+func TestParseLvl(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Lvl
+		err  bool
+	}{
+		{in: "info", want: LvlInfo},
+		{in: "notice", want: LvlNotice},
+		{in: "warn", want: LvlWarn},
+		{in: "warning", want: LvlWarn},
+		{in: "error", want: LvlError},
+		{in: "6", want: 6},
+		{in: "0", err: true},
+		{in: "-1", err: true},
+		{in: "fatal", err: true},
+	} {
+		got, err := ParseLvl(tc.in)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("ParseLvl(%q) = %v, %v, want %v, error: %v", tc.in, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestFindings(t *testing.T) {
+	s := compile(t, schema)
+	m := compile(t, `{}`)
+
+	for _, tc := range []struct {
+		lvl  Lvl
+		want []string // Severity, and field, of each finding.
+	}{
+		{
+			lvl:  LvlError,
+			want: []string{"error title", "error summary", "error meta.team"},
+		},
+		{
+			lvl:  LvlWarn,
+			want: []string{"error title", "warning status", "6 tags", "error summary", "error meta.team"},
+		},
+		{
+			lvl:  LvlInfo,
+			want: []string{"error title", "warning status", "notice owner", "info reviewed", "6 tags", "error summary", "error meta.team"},
+		},
+	} {
+		var got []string
+
+		for _, f := range Findings(m, s, Level(tc.lvl)) {
+			got = append(got, f.Severity+" "+f.Field)
+		}
+
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Findings(Level(%v)) = %q, want %q", tc.lvl, got, tc.want)
+		}
+	}
+}
+
+func TestFindingsMessage(t *testing.T) {
+	f := Findings(compile(t, `{status: "unknown", tags: []}`), compile(t, schema), Level(LvlWarn))
+
+	want := map[string]string{
+		"status":    "unknown status",
+		"tags":      "tags are required",
+		"meta.team": "missing team",
+	}
+
+	for _, x := range f {
+		if m, ok := want[x.Field]; ok && x.Message != m {
+			t.Errorf("Findings() %s = %q, want %q", x.Field, x.Message, m)
+		}
+	}
+}
+
+func TestVetUnreadable(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, s := range map[string]string{
+		"unclosed.md": "---\ntitle: Alpha\n",
+		"invalid.md":  "---\ntitle: [Alpha\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := Vet(dir, compile(t, schema))
+	if err != nil {
+		t.Fatalf("Vet() = %v", err)
+	}
+
+	if len(r) != 2 {
+		t.Fatalf("Vet() = %d files, want 2", len(r))
+	}
+
+	// Files whose metadata cannot be read have a finding, as well as an error.
+	for _, f := range r {
+		if len(f.Errors) != 1 || len(f.Findings) != 1 || f.Findings[0].Level != int(LvlError) || f.Findings[0].Message != f.Errors[0].Message {
+			t.Errorf("Vet() %s = %+v, %+v, want a single error finding", f.Name, f.Errors, f.Findings)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	errs, wrns := split([]report.Finding{
+		finding("a", "", LvlError),
+		finding("b", "", 9),
+		finding("c", "", LvlWarn),
+		finding("d", "", 5),
+		finding("e", "", LvlNotice),
+		finding("f", "", LvlInfo),
+	})
+
+	if len(errs) != 2 || len(wrns) != 2 {
+		t.Errorf("split() = %d errors, %d warnings, want 2, 2", len(errs), len(wrns))
+	}
+}
+
+// names returns the fields of the given findings.
+func names(f []report.Finding) []string {
+	var s []string
+
+	for _, x := range f {
+		s = append(s, x.Field)
+	}
+
+	return s
+}